@@ -2,6 +2,7 @@ package substrate
 
 import (
 	"bytes"
+	"context"
 	"crypto/ed25519"
 	"encoding/json"
 	"fmt"
@@ -78,6 +79,22 @@ func (a AccountID) MarshalJSON() ([]byte, error) {
 	return json.Marshal(address)
 }
 
+// UnmarshalJSON implementation
+func (a *AccountID) UnmarshalJSON(data []byte) error {
+	var address string
+	if err := json.Unmarshal(data, &address); err != nil {
+		return err
+	}
+
+	account, err := FromAddress(address)
+	if err != nil {
+		return err
+	}
+
+	*a = account
+	return nil
+}
+
 // FromAddress creates an AccountID from a SS58 address
 func FromAddress(address string) (account AccountID, err error) {
 	bytes := base58.Decode(address)
@@ -130,7 +147,7 @@ curl --header "Content-Type: application/json" \
   https://api.substrate01.threefold.io/activate
 */
 
-func (s *Substrate) activateAccount(identity Identity, activationURL string) error {
+func (s *Substrate) activateAccount(ctx context.Context, identity Identity, activationURL string) error {
 	var buf bytes.Buffer
 	if err := json.NewEncoder(&buf).Encode(map[string]string{
 		"substrateAccountID": identity.Address(),
@@ -138,7 +155,13 @@ func (s *Substrate) activateAccount(identity Identity, activationURL string) err
 		return errors.Wrap(err, "failed to build required body")
 	}
 
-	response, err := http.Post(activationURL, "application/json", &buf)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, activationURL, &buf)
+	if err != nil {
+		return errors.Wrap(err, "failed to build activation request")
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	response, err := http.DefaultClient.Do(request)
 	if err != nil {
 		return errors.Wrap(err, "failed to call activation service")
 	}
@@ -159,8 +182,13 @@ func (s *Substrate) activateAccount(identity Identity, activationURL string) err
 // a NO-OP operation unless the account funds are very low, it will then make
 // sure to reactivate the account (fund it) if the free tokes are <= ReactivateThreefold uTFT
 func (s *Substrate) EnsureAccount(identity Identity, activationURL, termsAndConditionsLink, terminsAndConditionsHash string) (info AccountInfo, err error) {
+	return s.EnsureAccountCtx(context.Background(), identity, activationURL, termsAndConditionsLink, terminsAndConditionsHash)
+}
+
+// EnsureAccountCtx is like EnsureAccount but takes a context
+func (s *Substrate) EnsureAccountCtx(ctx context.Context, identity Identity, activationURL, termsAndConditionsLink, terminsAndConditionsHash string) (info AccountInfo, err error) {
 	log.Debug().Str("account", identity.Address()).Msg("ensuring account")
-	cl, meta, err := s.GetClient()
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return info, err
 	}
@@ -170,7 +198,7 @@ func (s *Substrate) EnsureAccount(identity Identity, activationURL, termsAndCond
 	if errors.Is(err, ErrAccountNotFound) || info.Data.Free.Cmp(reactivateAt) <= 0 {
 		// account activation
 		log.Info().Uint64("funds", info.Data.Free.Uint64()).Str("account", identity.Address()).Msg("activating account")
-		if err = s.activateAccount(identity, activationURL); err != nil {
+		if err = s.activateAccount(ctx, identity, activationURL); err != nil {
 			return
 		}
 
@@ -184,7 +212,7 @@ func (s *Substrate) EnsureAccount(identity Identity, activationURL, termsAndCond
 		err = backoff.Retry(func() error {
 			info, err = s.getAccount(cl, meta, identity)
 			return err
-		}, exp)
+		}, backoff.WithContext(exp, ctx))
 	}
 
 	account, err := FromAddress(identity.Address())
@@ -192,7 +220,7 @@ func (s *Substrate) EnsureAccount(identity Identity, activationURL, termsAndCond
 		return info, errors.Wrap(err, "failed to get account id for identity")
 	}
 
-	conditions, err := s.SignedTermsAndConditions(account)
+	conditions, err := s.SignedTermsAndConditionsCtx(ctx, account)
 	if err != nil {
 		return info, err
 	}
@@ -201,7 +229,7 @@ func (s *Substrate) EnsureAccount(identity Identity, activationURL, termsAndCond
 		return info, nil
 	}
 
-	return info, s.AcceptTermsAndConditions(identity, termsAndConditionsLink, terminsAndConditionsHash)
+	return info, s.AcceptTermsAndConditionsCtx(ctx, identity, termsAndConditionsLink, terminsAndConditionsHash)
 }
 
 // Identity is a user identity
@@ -319,7 +347,7 @@ func (s *Substrate) getAccount(cl Conn, meta Meta, identity Identity) (info Acco
 		return
 	}
 
-	ok, err := cl.RPC.State.GetStorageLatest(key, &info)
+	ok, err := s.getStorage(cl, key, &info)
 	if err != nil || !ok {
 		if !ok {
 			return info, ErrAccountNotFound
@@ -333,7 +361,12 @@ func (s *Substrate) getAccount(cl Conn, meta Meta, identity Identity) (info Acco
 
 // GetAccount gets account info with secure key
 func (s *Substrate) GetAccount(identity Identity) (info AccountInfo, err error) {
-	cl, meta, err := s.GetClient()
+	return s.GetAccountCtx(context.Background(), identity)
+}
+
+// GetAccountCtx is like GetAccount but takes a context
+func (s *Substrate) GetAccountCtx(ctx context.Context, identity Identity) (info AccountInfo, err error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return info, err
 	}
@@ -343,7 +376,12 @@ func (s *Substrate) GetAccount(identity Identity) (info AccountInfo, err error)
 
 // GetAccountPublicInfo gets the info for a given account ID
 func (s *Substrate) GetAccountPublicInfo(account AccountID) (info AccountInfo, err error) {
-	cl, meta, err := s.GetClient()
+	return s.GetAccountPublicInfoCtx(context.Background(), account)
+}
+
+// GetAccountPublicInfoCtx is like GetAccountPublicInfo but takes a context
+func (s *Substrate) GetAccountPublicInfoCtx(ctx context.Context, account AccountID) (info AccountInfo, err error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return
 	}
@@ -353,7 +391,7 @@ func (s *Substrate) GetAccountPublicInfo(account AccountID) (info AccountInfo, e
 		return info, errors.Wrap(err, "failed to create substrate query key")
 	}
 
-	ok, err := cl.RPC.State.GetStorageLatest(key, &info)
+	ok, err := s.getStorage(cl, key, &info)
 	if err != nil || !ok {
 		if !ok {
 			return info, ErrAccountNotFound
@@ -366,7 +404,12 @@ func (s *Substrate) GetAccountPublicInfo(account AccountID) (info AccountInfo, e
 
 // GetBalance gets the balance for a given account ID
 func (s *Substrate) GetBalance(account AccountID) (balance Balance, err error) {
-	cl, meta, err := s.GetClient()
+	return s.GetBalanceCtx(context.Background(), account)
+}
+
+// GetBalanceCtx is like GetBalance but takes a context
+func (s *Substrate) GetBalanceCtx(ctx context.Context, account AccountID) (balance Balance, err error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return
 	}
@@ -377,7 +420,7 @@ func (s *Substrate) GetBalance(account AccountID) (balance Balance, err error) {
 	}
 
 	var info AccountInfo
-	ok, err := cl.RPC.State.GetStorageLatest(key, &info)
+	ok, err := s.getStorage(cl, key, &info)
 	balance = info.Data
 	if err != nil || !ok {
 		if !ok {
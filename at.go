@@ -0,0 +1,95 @@
+package substrate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/pkg/errors"
+)
+
+// ErrPinned is returned when calling extrinsics on a client pinned to a block
+var ErrPinned = fmt.Errorf("client is pinned to a block, it can't submit extrinsics")
+
+// At returns a read only view of the chain state at block. All getters of
+// the returned client read the storage at that block, and decode it with the
+// metadata of the runtime at that block. The view uses the connection of s,
+// so it must not be used after s is closed. Closing the view does not close
+// the connection
+func (s *Substrate) At(block types.Hash) (*Substrate, error) {
+	return s.AtCtx(context.Background(), block)
+}
+
+// AtCtx is like At but takes a context
+func (s *Substrate) AtCtx(ctx context.Context, block types.Hash) (*Substrate, error) {
+	cl, _, err := s.getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	meta, _, err := s.metadataAt(cl, block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Substrate{
+		cl:      s.cl,
+		meta:    meta,
+		cache:   s.cache,
+		nonces:  s.nonces,
+		genesis: s.genesis,
+		metrics: s.metrics,
+		at:      &block,
+		stop:    make(chan struct{}),
+		close:   func(*Substrate) {},
+	}, nil
+}
+
+// AtHeight is like At but takes a block number
+func (s *Substrate) AtHeight(height uint32) (*Substrate, error) {
+	return s.AtHeightCtx(context.Background(), height)
+}
+
+// AtHeightCtx is like AtHeight but takes a context
+func (s *Substrate) AtHeightCtx(ctx context.Context, height uint32) (*Substrate, error) {
+	cl, _, err := s.getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := cl.RPC.Chain.GetBlockHash(uint64(height))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get hash of block %d", height)
+	}
+
+	return s.AtCtx(ctx, block)
+}
+
+// Pinned returns the block the client is pinned to, if any
+func (s *Substrate) Pinned() (types.Hash, bool) {
+	if s.at == nil {
+		return types.Hash{}, false
+	}
+
+	return *s.at, true
+}
+
+// getStorage is like GetStorageLatest but reads the storage
+// at the pinned block if the client is pinned
+func (s *Substrate) getStorage(cl Conn, key types.StorageKey, target interface{}) (bool, error) {
+	if s.at != nil {
+		return cl.RPC.State.GetStorage(key, target, *s.at)
+	}
+
+	return cl.RPC.State.GetStorageLatest(key, target)
+}
+
+// getStorageRaw is like GetStorageRawLatest but reads the storage
+// at the pinned block if the client is pinned
+func (s *Substrate) getStorageRaw(cl Conn, key types.StorageKey) (*types.StorageDataRaw, error) {
+	if s.at != nil {
+		return cl.RPC.State.GetStorageRaw(key, *s.at)
+	}
+
+	return cl.RPC.State.GetStorageRawLatest(key)
+}
@@ -0,0 +1,74 @@
+package substrate
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAt(t *testing.T) {
+	node := newFakeNode(t)
+	old := types.NewHash([]byte{1})
+
+	// the old block runs spec 1, the chain head runs spec 2
+	node.handle("state_getRuntimeVersion", func(params []json.RawMessage) (interface{}, error) {
+		spec := types.U32(2)
+		if len(params) > 0 && string(params[0]) == `"`+old.Hex()+`"` {
+			spec = 1
+		}
+		return types.RuntimeVersion{SpecName: "fake", SpecVersion: spec}, nil
+	})
+	node.handle("chain_getBlockHash", func(params []json.RawMessage) (interface{}, error) {
+		if len(params) > 0 && string(params[0]) == "10" {
+			return old.Hex(), nil
+		}
+		return types.Hash{}.Hex(), nil
+	})
+
+	sub, identity := callTestClient(t, node)
+	_, latest, err := sub.GetClient()
+	require.NoError(t, err)
+
+	pinned, err := sub.AtHeight(10)
+	require.NoError(t, err)
+	defer pinned.Close()
+
+	block, ok := pinned.Pinned()
+	require.True(t, ok)
+	require.Equal(t, old, block)
+	_, ok = sub.Pinned()
+	require.False(t, ok)
+
+	// metadata of spec 1 is downloaded at the old block
+	cl, meta, err := pinned.GetClient()
+	require.NoError(t, err)
+	require.NotSame(t, latest, meta)
+	require.Equal(t, `"`+old.Hex()+`"`, string(node.lastParams("state_getMetadata")[0]))
+
+	// storage is read at the pinned block
+	now := time.Now().Add(-time.Hour).Truncate(time.Millisecond)
+	node.setTime(now)
+	stamp, err := pinned.Time()
+	require.NoError(t, err)
+	require.True(t, now.Equal(stamp))
+	params := node.lastParams("state_getStorage")
+	require.Len(t, params, 2)
+	require.Equal(t, `"`+old.Hex()+`"`, string(params[1]))
+
+	_, err = sub.Time()
+	require.NoError(t, err)
+	require.Len(t, node.lastParams("state_getStorage"), 1)
+
+	_, err = pinned.Call(cl, meta, identity, types.Call{})
+	require.ErrorIs(t, err, ErrPinned)
+	_, err = pinned.CallOnce(cl, meta, identity, types.Call{})
+	require.ErrorIs(t, err, ErrPinned)
+
+	// closing the view keeps the connection open
+	pinned.Close()
+	_, err = sub.Time()
+	require.NoError(t, err)
+}
@@ -0,0 +1,175 @@
+package substrate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
+)
+
+// BatchResult is the result of a single call in a batch
+type BatchResult struct {
+	// Executed is false if the call was not executed because
+	// the batch was interrupted by a previous call
+	Executed bool
+	// Err is the error of the call if it failed
+	Err error
+}
+
+// Batch sends calls in a single extrinsic. Calls are executed in order until
+// one fails, the calls before the failed one are not reverted.
+// response.BatchResults holds the result of each call
+func (s *Substrate) Batch(identity Identity, calls []types.Call, opts ...CallOption) (*CallResponse, error) {
+	return s.BatchCtx(context.Background(), identity, calls, opts...)
+}
+
+// BatchCtx is like Batch but takes a context
+func (s *Substrate) BatchCtx(ctx context.Context, identity Identity, calls []types.Call, opts ...CallOption) (*CallResponse, error) {
+	return s.batch(ctx, "Utility.batch", identity, calls, opts)
+}
+
+// BatchAll sends calls in a single extrinsic. Either all calls succeed or
+// none of them is applied, the error of the failed call is returned
+func (s *Substrate) BatchAll(identity Identity, calls []types.Call, opts ...CallOption) (*CallResponse, error) {
+	return s.BatchAllCtx(context.Background(), identity, calls, opts...)
+}
+
+// BatchAllCtx is like BatchAll but takes a context
+func (s *Substrate) BatchAllCtx(ctx context.Context, identity Identity, calls []types.Call, opts ...CallOption) (*CallResponse, error) {
+	return s.batch(ctx, "Utility.batch_all", identity, calls, opts)
+}
+
+// ForceBatch sends calls in a single extrinsic. All calls are executed even
+// if some of them fail. response.BatchResults holds the result of each call
+func (s *Substrate) ForceBatch(identity Identity, calls []types.Call, opts ...CallOption) (*CallResponse, error) {
+	return s.ForceBatchCtx(context.Background(), identity, calls, opts...)
+}
+
+// ForceBatchCtx is like ForceBatch but takes a context
+func (s *Substrate) ForceBatchCtx(ctx context.Context, identity Identity, calls []types.Call, opts ...CallOption) (*CallResponse, error) {
+	return s.batch(ctx, "Utility.force_batch", identity, calls, opts)
+}
+
+// CancelContracts cancels multiple contracts in a single extrinsic. All
+// contracts are canceled even if some of the cancellations fail
+func (s *Substrate) CancelContracts(identity Identity, contracts ...uint64) error {
+	return s.CancelContractsCtx(context.Background(), identity, contracts...)
+}
+
+// CancelContractsCtx is like CancelContracts but takes a context
+func (s *Substrate) CancelContractsCtx(ctx context.Context, identity Identity, contracts ...uint64) error {
+	_, meta, err := s.getClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	calls := make([]types.Call, 0, len(contracts))
+	for _, contract := range contracts {
+		c, err := types.NewCall(meta, "SmartContractModule.cancel_contract", contract)
+		if err != nil {
+			return errors.Wrap(err, "failed to cancel call")
+		}
+		calls = append(calls, c)
+	}
+
+	response, err := s.ForceBatchCtx(ctx, identity, calls)
+	if err != nil {
+		return errors.Wrap(err, "failed to cancel contracts")
+	}
+
+	var failed []string
+	for i, result := range response.BatchResults {
+		if result.Err != nil {
+			failed = append(failed, fmt.Sprintf("%d: %s", contracts[i], result.Err))
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to cancel contracts (%s)", strings.Join(failed, ", "))
+	}
+
+	return nil
+}
+
+func (s *Substrate) batch(ctx context.Context, method string, identity Identity, calls []types.Call, opts []CallOption) (*CallResponse, error) {
+	if len(calls) == 0 {
+		return nil, fmt.Errorf("no calls to batch")
+	}
+
+	cl, meta, err := s.getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := types.NewCall(meta, method, calls)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create call")
+	}
+
+	response, err := s.CallCtx(ctx, cl, meta, identity, c, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Events == nil {
+		// fire and forget, results are not known
+		return response, nil
+	}
+
+	index, ok := extrinsicIndex(response.Block, response.ExtrinsicHash)
+	if !ok {
+		return nil, fmt.Errorf("extrinsic '%s' not found in block '%s'", response.ExtrinsicHash.Hex(), response.Hash.Hex())
+	}
+
+	response.BatchResults = batchResults(meta, response.Events, index, len(calls))
+	return response, nil
+}
+
+// batchResults maps the utility events of the extrinsic at index to the
+// results of each call in the batch
+func batchResults(meta Meta, events *EventRecords, index uint32, count int) []BatchResult {
+	results := make([]BatchResult, count)
+
+	item := 0
+	for _, ref := range events.Order {
+		if !ref.Phase.IsApplyExtrinsic || ref.Phase.AsApplyExtrinsic != index || item >= count {
+			continue
+		}
+
+		switch ref.Name {
+		case "Utility_ItemCompleted":
+			results[item] = BatchResult{Executed: true}
+			item++
+		case "Utility_ItemFailed":
+			event := events.Utility_ItemFailed[ref.Index]
+			results[item] = BatchResult{Executed: true, Err: dispatchError(meta, event.DispatchError)}
+			item++
+		case "Utility_BatchInterrupted":
+			event := events.Utility_BatchInterrupted[ref.Index]
+			if int(event.Index) < count {
+				results[event.Index] = BatchResult{Executed: true, Err: dispatchError(meta, event.DispatchError)}
+			}
+		}
+	}
+
+	return results
+}
+
+// extrinsicIndex finds the index of the extrinsic with the given hash in block
+func extrinsicIndex(block *types.SignedBlock, hash types.Hash) (uint32, bool) {
+	for i, ext := range block.Block.Extrinsics {
+		encoded, err := types.Encode(ext)
+		if err != nil {
+			continue
+		}
+
+		if blake2b.Sum256(encoded) == hash {
+			return uint32(i), true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,99 @@
+package substrate
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchResults(t *testing.T) {
+	apply := func(index uint32) types.Phase {
+		return types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: index}
+	}
+
+	twinNotExists := types.DispatchError{IsModule: true, ModuleError: types.ModuleError{Index: 12, Error: 0}}
+
+	var events EventRecords
+	events.Utility_ItemCompleted = []types.EventUtilityItemCompleted{
+		{Phase: apply(0)}, {Phase: apply(1)}, {Phase: apply(1)},
+	}
+	events.Utility_ItemFailed = []types.EventUtilityItemFailed{
+		{Phase: apply(1), DispatchError: twinNotExists},
+	}
+	events.Order = []EventRef{
+		// another extrinsic
+		{Phase: apply(0), Name: "Utility_ItemCompleted", Index: 0},
+		{Phase: apply(1), Name: "Utility_ItemCompleted", Index: 1},
+		{Phase: apply(1), Name: "Utility_ItemFailed", Index: 0},
+		{Phase: apply(1), Name: "Utility_ItemCompleted", Index: 2},
+	}
+
+	results := batchResults(nil, &events, 1, 3)
+	require.Len(t, results, 3)
+	require.True(t, results[0].Executed)
+	require.NoError(t, results[0].Err)
+	require.True(t, results[1].Executed)
+	require.EqualError(t, results[1].Err, "TwinNotExists")
+	require.True(t, results[2].Executed)
+	require.NoError(t, results[2].Err)
+}
+
+func TestBatchInterrupted(t *testing.T) {
+	block := types.NewHash([]byte{1})
+	node := newCallTestNode(t,
+		map[string]interface{}{"inBlock": block.Hex()},
+	)
+
+	// include the submitted extrinsic in the block
+	node.handle("chain_getBlock", func(params []json.RawMessage) (interface{}, error) {
+		var ext string
+		if err := json.Unmarshal(node.lastParams("author_submitAndWatchExtrinsic")[0], &ext); err != nil {
+			return nil, err
+		}
+
+		return map[string]interface{}{
+			"block": map[string]interface{}{
+				"header": map[string]interface{}{
+					"parentHash":     types.Hash{}.Hex(),
+					"number":         "0x1",
+					"stateRoot":      types.Hash{}.Hex(),
+					"extrinsicsRoot": types.Hash{}.Hex(),
+					"digest":         map[string]interface{}{"logs": []string{}},
+				},
+				"extrinsics": []string{ext},
+			},
+		}, nil
+	})
+
+	key, err := types.CreateStorageKey(node.meta, "System", "Events", nil)
+	require.NoError(t, err)
+
+	phase := []byte{0, 0, 0, 0, 0}
+	events := []byte{2 << 2}
+	// Utility.ItemCompleted
+	events = append(events, phase...)
+	events = append(events, 1, 2, 0)
+	// Utility.BatchInterrupted at call 1 with Democracy.ValueLow
+	events = append(events, phase...)
+	events = append(events, 1, 0, 1, 0, 0, 0, 3, 12, 0, 0, 0, 0, 0)
+	node.setStorage(key, events)
+
+	sub, identity := callTestClient(t, node)
+	_, meta, err := sub.GetClient()
+	require.NoError(t, err)
+
+	remark, err := types.NewCall(meta, "System.remark", []byte("hello"))
+	require.NoError(t, err)
+
+	response, err := sub.Batch(identity, []types.Call{remark, remark, remark})
+	require.NoError(t, err)
+	require.Equal(t, block, response.Hash)
+	require.Equal(t, []BatchResult{
+		{Executed: true},
+		{Executed: true, Err: response.BatchResults[1].Err},
+		{},
+	}, response.BatchResults)
+	require.EqualError(t, response.BatchResults[1].Err, "ValueLow")
+}
@@ -1,12 +1,19 @@
 package substrate
 
 import (
+	"context"
+
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/pkg/errors"
 )
 
 func (s *Substrate) GetCurrentHeight() (uint32, error) {
-	cl, meta, err := s.GetClient()
+	return s.GetCurrentHeightCtx(context.Background())
+}
+
+// GetCurrentHeightCtx is like GetCurrentHeight but takes a context
+func (s *Substrate) GetCurrentHeightCtx(ctx context.Context) (uint32, error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -18,7 +25,7 @@ func (s *Substrate) GetCurrentHeight() (uint32, error) {
 		return 0, err
 	}
 
-	ok, err := cl.RPC.State.GetStorageLatest(key, &blockNumber)
+	ok, err := s.getStorage(cl, key, &blockNumber)
 	if err != nil {
 		return 0, err
 	}
@@ -31,7 +38,12 @@ func (s *Substrate) GetCurrentHeight() (uint32, error) {
 }
 
 func (s *Substrate) FetchEventsForBlockRange(start uint32, end uint32) (types.StorageKey, []types.StorageChangeSet, error) {
-	cl, meta, err := s.GetClient()
+	return s.FetchEventsForBlockRangeCtx(context.Background(), start, end)
+}
+
+// FetchEventsForBlockRangeCtx is like FetchEventsForBlockRange but takes a context
+func (s *Substrate) FetchEventsForBlockRangeCtx(ctx context.Context, start uint32, end uint32) (types.StorageKey, []types.StorageChangeSet, error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -60,7 +72,12 @@ func (s *Substrate) FetchEventsForBlockRange(start uint32, end uint32) (types.St
 }
 
 func (s *Substrate) GetEventsForBlock(start uint32) (*EventRecords, error) {
-	cl, _, err := s.GetClient()
+	return s.GetEventsForBlockCtx(context.Background(), start)
+}
+
+// GetEventsForBlockCtx is like GetEventsForBlock but takes a context
+func (s *Substrate) GetEventsForBlockCtx(ctx context.Context, start uint32) (*EventRecords, error) {
+	cl, _, err := s.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -69,11 +86,18 @@ func (s *Substrate) GetEventsForBlock(start uint32) (*EventRecords, error) {
 	if err != nil {
 		return nil, err
 	}
-	meta, err := cl.RPC.State.GetMetadata(block)
+
+	meta, spec, err := s.metadataAt(cl, block)
 	if err != nil {
 		return nil, err
 	}
 
+	return getEventsAt(cl, meta, spec, block)
+}
+
+// getEventsAt gets and decodes the events of block using the block metadata
+// and runtime spec version
+func getEventsAt(cl Conn, meta Meta, spec uint32, block types.Hash) (*EventRecords, error) {
 	key, err := types.CreateStorageKey(meta, "System", "Events", nil)
 	if err != nil {
 		return nil, err
@@ -90,7 +114,7 @@ func (s *Substrate) GetEventsForBlock(start uint32) (*EventRecords, error) {
 	}
 
 	events := EventRecords{}
-	err = types.EventRecordsRaw(storageData).DecodeEventRecords(meta, &events)
+	err = decodeEventRecords(meta, spec, types.EventRecordsRaw(storageData), &events)
 	if err != nil {
 		return nil, err
 	}
@@ -99,7 +123,12 @@ func (s *Substrate) GetEventsForBlock(start uint32) (*EventRecords, error) {
 }
 
 func (s *Substrate) GetBlock(block types.Hash) (*types.SignedBlock, error) {
-	cl, _, err := s.GetClient()
+	return s.GetBlockCtx(context.Background(), block)
+}
+
+// GetBlockCtx is like GetBlock but takes a context
+func (s *Substrate) GetBlockCtx(ctx context.Context, block types.Hash) (*types.SignedBlock, error) {
+	cl, _, err := s.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -108,7 +137,12 @@ func (s *Substrate) GetBlock(block types.Hash) (*types.SignedBlock, error) {
 }
 
 func (s *Substrate) GetBlockByNumber(blockNumber types.U32) (*types.SignedBlock, error) {
-	cl, _, err := s.GetClient()
+	return s.GetBlockByNumberCtx(context.Background(), blockNumber)
+}
+
+// GetBlockByNumberCtx is like GetBlockByNumber but takes a context
+func (s *Substrate) GetBlockByNumberCtx(ctx context.Context, blockNumber types.U32) (*types.SignedBlock, error) {
+	cl, _, err := s.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
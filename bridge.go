@@ -1,6 +1,7 @@
 package substrate
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
@@ -12,7 +13,12 @@ var (
 )
 
 func (s *Substrate) IsValidator(identity Identity) (exists bool, err error) {
-	cl, meta, err := s.GetClient()
+	return s.IsValidatorCtx(context.Background(), identity)
+}
+
+// IsValidatorCtx is like IsValidator but takes a context
+func (s *Substrate) IsValidatorCtx(ctx context.Context, identity Identity) (exists bool, err error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return false, err
 	}
@@ -24,7 +30,7 @@ func (s *Substrate) IsValidator(identity Identity) (exists bool, err error) {
 		return
 	}
 
-	ok, err := cl.RPC.State.GetStorageLatest(key, &validators)
+	ok, err := s.getStorage(cl, key, &validators)
 	if err != nil || !ok {
 		if !ok {
 			return false, errValidatorNotFound
@@ -1,6 +1,7 @@
 package substrate
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 
@@ -22,8 +23,13 @@ type BurnTransaction struct {
 	SequenceNumber types.U64
 }
 
-func (s *Substrate) ProposeBurnTransactionOrAddSig(identity Identity, txID uint64, target string, amount *big.Int, signature string, stellarAddress string, sequence_number uint64) error {
-	cl, meta, err := s.GetClient()
+func (s *Substrate) ProposeBurnTransactionOrAddSig(identity Identity, txID uint64, target string, amount *big.Int, signature string, stellarAddress string, sequence_number uint64, opts ...CallOption) error {
+	return s.ProposeBurnTransactionOrAddSigCtx(context.Background(), identity, txID, target, amount, signature, stellarAddress, sequence_number, opts...)
+}
+
+// ProposeBurnTransactionOrAddSigCtx is like ProposeBurnTransactionOrAddSig but takes a context
+func (s *Substrate) ProposeBurnTransactionOrAddSigCtx(ctx context.Context, identity Identity, txID uint64, target string, amount *big.Int, signature string, stellarAddress string, sequence_number uint64, opts ...CallOption) error {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return err
 	}
@@ -36,7 +42,7 @@ func (s *Substrate) ProposeBurnTransactionOrAddSig(identity Identity, txID uint6
 		return errors.Wrap(err, "failed to create call")
 	}
 
-	_, err = s.Call(cl, meta, identity, c)
+	_, err = s.CallCtx(ctx, cl, meta, identity, c, opts...)
 	if err != nil {
 		return errors.Wrap(err, "failed to propose burn transaction")
 	}
@@ -44,8 +50,13 @@ func (s *Substrate) ProposeBurnTransactionOrAddSig(identity Identity, txID uint6
 	return nil
 }
 
-func (s *Substrate) SetBurnTransactionExecuted(identity Identity, txID uint64) error {
-	cl, meta, err := s.GetClient()
+func (s *Substrate) SetBurnTransactionExecuted(identity Identity, txID uint64, opts ...CallOption) error {
+	return s.SetBurnTransactionExecutedCtx(context.Background(), identity, txID, opts...)
+}
+
+// SetBurnTransactionExecutedCtx is like SetBurnTransactionExecuted but takes a context
+func (s *Substrate) SetBurnTransactionExecutedCtx(ctx context.Context, identity Identity, txID uint64, opts ...CallOption) error {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return err
 	}
@@ -56,7 +67,7 @@ func (s *Substrate) SetBurnTransactionExecuted(identity Identity, txID uint64) e
 		return errors.Wrap(err, "failed to create call")
 	}
 
-	_, err = s.Call(cl, meta, identity, c)
+	_, err = s.CallCtx(ctx, cl, meta, identity, c, opts...)
 	if err != nil {
 		return errors.Wrap(err, "failed to set burn transaction executed")
 	}
@@ -65,7 +76,12 @@ func (s *Substrate) SetBurnTransactionExecuted(identity Identity, txID uint64) e
 }
 
 func (s *Substrate) GetBurnTransaction(burnTransactionID types.U64) (*BurnTransaction, error) {
-	cl, meta, err := s.GetClient()
+	return s.GetBurnTransactionCtx(context.Background(), burnTransactionID)
+}
+
+// GetBurnTransactionCtx is like GetBurnTransaction but takes a context
+func (s *Substrate) GetBurnTransactionCtx(ctx context.Context, burnTransactionID types.U64) (*BurnTransaction, error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -82,7 +98,7 @@ func (s *Substrate) GetBurnTransaction(burnTransactionID types.U64) (*BurnTransa
 		return nil, err
 	}
 
-	ok, err := cl.RPC.State.GetStorageLatest(key, &burnTx)
+	ok, err := s.getStorage(cl, key, &burnTx)
 	if err != nil {
 		return nil, err
 	}
@@ -95,7 +111,12 @@ func (s *Substrate) GetBurnTransaction(burnTransactionID types.U64) (*BurnTransa
 }
 
 func (s *Substrate) IsBurnedAlready(burnTransactionID types.U64) (exists bool, err error) {
-	cl, meta, err := s.GetClient()
+	return s.IsBurnedAlreadyCtx(context.Background(), burnTransactionID)
+}
+
+// IsBurnedAlreadyCtx is like IsBurnedAlready but takes a context
+func (s *Substrate) IsBurnedAlreadyCtx(ctx context.Context, burnTransactionID types.U64) (exists bool, err error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return false, err
 	}
@@ -112,7 +133,7 @@ func (s *Substrate) IsBurnedAlready(burnTransactionID types.U64) (exists bool, e
 		return
 	}
 
-	ok, err := cl.RPC.State.GetStorageLatest(key, &burnTx)
+	ok, err := s.getStorage(cl, key, &burnTx)
 	if err != nil {
 		return false, err
 	}
@@ -0,0 +1,132 @@
+package substrate
+
+import (
+	"fmt"
+	"math/bits"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	// maxMortalPeriod is the longest period a mortal extrinsic can be valid for
+	maxMortalPeriod = 1 << 16
+	// minMortalPeriod is the shortest period a mortal extrinsic can be valid for
+	minMortalPeriod = 4
+)
+
+// Finality is the level of inclusion of an extrinsic
+type Finality int
+
+const (
+	// WaitInBlock waits until the extrinsic is included in a block. This is the default
+	WaitInBlock Finality = iota
+	// WaitFinalized waits until the block that includes the extrinsic is finalized
+	WaitFinalized
+	// FireAndForget returns as soon as the extrinsic is accepted by the node
+	FireAndForget
+)
+
+func (f Finality) String() string {
+	switch f {
+	case WaitInBlock:
+		return "in-block"
+	case WaitFinalized:
+		return "finalized"
+	case FireAndForget:
+		return "fire-and-forget"
+	default:
+		return fmt.Sprintf("Finality(%d)", int(f))
+	}
+}
+
+// CallOptions configures how an extrinsic is submitted
+type CallOptions struct {
+	// Finality the call waits for before returning
+	Finality Finality
+	// MortalPeriod is the number of blocks the extrinsic is valid for, starting
+	// from the best block. Zero means the extrinsic is immortal
+	MortalPeriod uint64
+	// Tip is paid on top of the extrinsic fees to increase its priority
+	Tip uint64
+	// DryRunFirst dry runs the extrinsic before submitting it, the call
+	// is not submitted if the dry run fails
+	DryRunFirst bool
+}
+
+// CallOption sets a call option
+type CallOption func(*CallOptions)
+
+// WithFinality sets the finality level the call waits for
+func WithFinality(finality Finality) CallOption {
+	return func(o *CallOptions) {
+		o.Finality = finality
+	}
+}
+
+// WithMortalEra makes the extrinsic valid only for period blocks starting from
+// the current best block. The period is rounded up to a power of two
+func WithMortalEra(period uint64) CallOption {
+	return func(o *CallOptions) {
+		o.MortalPeriod = period
+	}
+}
+
+// WithTip sets the tip paid with the extrinsic
+func WithTip(tip uint64) CallOption {
+	return func(o *CallOptions) {
+		o.Tip = tip
+	}
+}
+
+// WithDryRunFirst dry runs the extrinsic before submitting it (see DryRun)
+func WithDryRunFirst() CallOption {
+	return func(o *CallOptions) {
+		o.DryRunFirst = true
+	}
+}
+
+func newCallOptions(opts []CallOption) CallOptions {
+	var o CallOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// newMortalEra creates a mortal era that starts at block current and is valid
+// for period blocks. It follows the substrate Era::mortal encoding
+func newMortalEra(current, period uint64) types.ExtrinsicEra {
+	if period > maxMortalPeriod/2 {
+		// next power of two would exceed the max period
+		period = maxMortalPeriod
+	} else if period > 0 {
+		period = 1 << bits.Len64(period-1)
+	}
+
+	if period < minMortalPeriod {
+		period = minMortalPeriod
+	}
+
+	phase := current % period
+	quantizeFactor := period >> 12
+	if quantizeFactor < 1 {
+		quantizeFactor = 1
+	}
+
+	low := uint64(bits.TrailingZeros64(period) - 1)
+	if low < 1 {
+		low = 1
+	} else if low > 15 {
+		low = 15
+	}
+
+	encoded := uint16(low | (phase/quantizeFactor)<<4)
+	return types.ExtrinsicEra{
+		IsMortalEra: true,
+		AsMortalEra: types.MortalEra{
+			First:  byte(encoded),
+			Second: byte(encoded >> 8),
+		},
+	}
+}
@@ -0,0 +1,166 @@
+package substrate
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/require"
+)
+
+// newCallTestNode creates a fake node that accepts extrinsics, the extrinsic
+// statuses are sent to the client once it watches the extrinsic
+func newCallTestNode(t *testing.T, statuses ...interface{}) *fakeNode {
+	node := newFakeNode(t)
+	node.subscription("author_submitAndWatchExtrinsic", "author_unwatchExtrinsic")
+
+	key, err := types.CreateStorageKey(node.meta, "System", "Events", nil)
+	require.NoError(t, err)
+	// no events
+	node.setStorage(key, []byte{0})
+
+	go func() {
+		for node.subscribers("author_submitAndWatchExtrinsic") == 0 {
+			time.Sleep(5 * time.Millisecond)
+		}
+
+		for _, status := range statuses {
+			node.publish("author_submitAndWatchExtrinsic", "author_extrinsicUpdate", status)
+		}
+	}()
+
+	return node
+}
+
+func callTestClient(t *testing.T, node *fakeNode) (*Substrate, Identity) {
+	sub := testClient(t, node, DefaultManagerOptions())
+
+	identity, err := NewIdentityFromSr25519Phrase("//Alice")
+	require.NoError(t, err)
+
+	return sub, identity
+}
+
+func TestCallWaitInBlock(t *testing.T) {
+	block := types.NewHash([]byte{1})
+	node := newCallTestNode(t,
+		"ready",
+		map[string]interface{}{"inBlock": block.Hex()},
+	)
+
+	sub, identity := callTestClient(t, node)
+	cl, meta, err := sub.GetClient()
+	require.NoError(t, err)
+
+	response, err := sub.Call(cl, meta, identity, types.Call{})
+	require.NoError(t, err)
+	require.Equal(t, block, response.Hash)
+	require.Equal(t, WaitInBlock, response.Finality)
+	require.Equal(t, types.Hash{}, response.FinalizedHash)
+	require.NotEqual(t, types.Hash{}, response.ExtrinsicHash)
+}
+
+func TestCallWaitFinalized(t *testing.T) {
+	retracted := types.NewHash([]byte{1})
+	block := types.NewHash([]byte{2})
+	node := newCallTestNode(t,
+		"ready",
+		map[string]interface{}{"inBlock": retracted.Hex()},
+		map[string]interface{}{"retracted": retracted.Hex()},
+		map[string]interface{}{"inBlock": block.Hex()},
+		map[string]interface{}{"finalized": block.Hex()},
+	)
+
+	sub, identity := callTestClient(t, node)
+	cl, meta, err := sub.GetClient()
+	require.NoError(t, err)
+
+	response, err := sub.Call(cl, meta, identity, types.Call{}, WithFinality(WaitFinalized))
+	require.NoError(t, err)
+	require.Equal(t, block, response.Hash)
+	require.Equal(t, WaitFinalized, response.Finality)
+	require.Equal(t, block, response.FinalizedHash)
+}
+
+func TestCallFireAndForget(t *testing.T) {
+	node := newFakeNode(t)
+	extrinsic := types.NewHash([]byte{3})
+	node.handle("author_submitExtrinsic", func(params []json.RawMessage) (interface{}, error) {
+		return extrinsic.Hex(), nil
+	})
+
+	sub, identity := callTestClient(t, node)
+	cl, meta, err := sub.GetClient()
+	require.NoError(t, err)
+
+	response, err := sub.Call(cl, meta, identity, types.Call{}, WithFinality(FireAndForget))
+	require.NoError(t, err)
+	require.Equal(t, FireAndForget, response.Finality)
+	require.Equal(t, extrinsic, response.ExtrinsicHash)
+	require.Nil(t, response.Events)
+	require.Equal(t, 1, node.count("author_submitExtrinsic"))
+	require.Equal(t, 0, node.count("author_submitAndWatchExtrinsic"))
+}
+
+func TestNewMortalEra(t *testing.T) {
+	cases := []struct {
+		current uint64
+		period  uint64
+		first   byte
+		second  byte
+	}{
+		{current: 42, period: 64, first: 5 + 42%16*16, second: 42 / 16},
+		// period is rounded up to the next power of two
+		{current: 42, period: 50, first: 5 + 42%16*16, second: 42 / 16},
+		// phase is quantized for long periods
+		{current: 20000, period: 32768, first: 14 + 2500%16*16, second: 2500 / 16},
+	}
+
+	for _, c := range cases {
+		era := newMortalEra(c.current, c.period)
+		require.True(t, era.IsMortalEra)
+		require.Equal(t, c.first, era.AsMortalEra.First)
+		require.Equal(t, c.second, era.AsMortalEra.Second)
+	}
+}
+
+func TestCallMortalEraWithTip(t *testing.T) {
+	node := newFakeNode(t)
+	node.handle("chain_getHeader", func(params []json.RawMessage) (interface{}, error) {
+		return map[string]interface{}{
+			"parentHash":     types.Hash{}.Hex(),
+			"number":         "0x2a",
+			"stateRoot":      types.Hash{}.Hex(),
+			"extrinsicsRoot": types.Hash{}.Hex(),
+			"digest":         map[string]interface{}{"logs": []string{}},
+		}, nil
+	})
+
+	submitted := make(chan string, 1)
+	node.handle("author_submitExtrinsic", func(params []json.RawMessage) (interface{}, error) {
+		var ext string
+		if err := json.Unmarshal(params[0], &ext); err != nil {
+			return nil, err
+		}
+		submitted <- ext
+		return types.Hash{}.Hex(), nil
+	})
+
+	sub, identity := callTestClient(t, node)
+	cl, meta, err := sub.GetClient()
+	require.NoError(t, err)
+
+	_, err = sub.Call(cl, meta, identity, types.Call{},
+		WithFinality(FireAndForget),
+		WithMortalEra(64),
+		WithTip(10),
+	)
+	require.NoError(t, err)
+
+	var ext types.Extrinsic
+	require.NoError(t, types.DecodeFromHex(<-submitted, &ext))
+	require.True(t, ext.IsSigned())
+	require.Equal(t, newMortalEra(42, 64), ext.Signature.Era)
+	require.Equal(t, types.NewUCompactFromUInt(10), ext.Signature.Tip)
+}
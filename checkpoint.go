@@ -0,0 +1,131 @@
+package substrate
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/pkg/errors"
+)
+
+// Checkpoint is the last block processed by an event processor
+type Checkpoint struct {
+	Number uint32
+	Hash   types.Hash
+}
+
+// CheckpointStore persists the checkpoint of an event processor
+type CheckpointStore interface {
+	// Load returns the last saved checkpoint, ok is false if no checkpoint was saved yet
+	Load() (cp Checkpoint, ok bool, err error)
+	// Save saves the checkpoint
+	Save(cp Checkpoint) error
+}
+
+// MemoryCheckpointStore keeps the checkpoint in memory, the zero value is ready to use
+type MemoryCheckpointStore struct {
+	m  sync.Mutex
+	cp *Checkpoint
+}
+
+var _ CheckpointStore = (*MemoryCheckpointStore)(nil)
+
+// Load implements CheckpointStore
+func (s *MemoryCheckpointStore) Load() (Checkpoint, bool, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if s.cp == nil {
+		return Checkpoint{}, false, nil
+	}
+
+	return *s.cp, true, nil
+}
+
+// Save implements CheckpointStore
+func (s *MemoryCheckpointStore) Save(cp Checkpoint) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.cp = &cp
+	return nil
+}
+
+// FileCheckpointStore keeps the checkpoint in a json file
+type FileCheckpointStore struct {
+	path string
+	m    sync.Mutex
+}
+
+var _ CheckpointStore = (*FileCheckpointStore)(nil)
+
+// NewFileCheckpointStore creates a checkpoint store that saves to the file at path
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return &FileCheckpointStore{path: path}
+}
+
+type fileCheckpoint struct {
+	Number uint32 `json:"number"`
+	Hash   string `json:"hash"`
+}
+
+// Load implements CheckpointStore
+func (s *FileCheckpointStore) Load() (Checkpoint, bool, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return Checkpoint{}, false, nil
+	} else if err != nil {
+		return Checkpoint{}, false, errors.Wrap(err, "failed to read checkpoint file")
+	}
+
+	var saved fileCheckpoint
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return Checkpoint{}, false, errors.Wrap(err, "failed to decode checkpoint file")
+	}
+
+	hash, err := types.NewHashFromHexString(saved.Hash)
+	if err != nil {
+		return Checkpoint{}, false, errors.Wrap(err, "invalid checkpoint block hash")
+	}
+
+	return Checkpoint{Number: saved.Number, Hash: hash}, true, nil
+}
+
+// Save implements CheckpointStore. The file is replaced atomically so a crash
+// never leaves a partially written checkpoint
+func (s *FileCheckpointStore) Save(cp Checkpoint) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	data, err := json.Marshal(fileCheckpoint{Number: cp.Number, Hash: cp.Hash.Hex()})
+	if err != nil {
+		return errors.Wrap(err, "failed to encode checkpoint")
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return errors.Wrap(err, "failed to create checkpoint file")
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "failed to write checkpoint file")
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "failed to sync checkpoint file")
+	}
+
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "failed to close checkpoint file")
+	}
+
+	return errors.Wrap(os.Rename(tmp.Name(), s.path), "failed to save checkpoint file")
+}
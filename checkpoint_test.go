@@ -0,0 +1,31 @@
+package substrate
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileCheckpointStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+
+	store := NewFileCheckpointStore(path)
+	_, ok, err := store.Load()
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	cp := Checkpoint{Number: 42, Hash: types.NewHash([]byte{1, 2, 3})}
+	require.NoError(t, store.Save(cp))
+	require.NoError(t, store.Save(Checkpoint{Number: 43, Hash: cp.Hash}))
+
+	loaded, ok, err := NewFileCheckpointStore(path).Load()
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, Checkpoint{Number: 43, Hash: cp.Hash}, loaded)
+
+	matches, err := filepath.Glob(filepath.Join(filepath.Dir(path), "*.tmp"))
+	require.NoError(t, err)
+	require.Empty(t, matches)
+}
@@ -0,0 +1,82 @@
+package substrate
+
+import (
+	"context"
+
+	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+)
+
+// contextCaller is implemented by clients that can bind a
+// single call to a context
+type contextCaller interface {
+	CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error
+}
+
+// ctxClient is a client where all calls are bound to a context. Calls
+// are aborted once the context is canceled or its deadline is exceeded
+type ctxClient struct {
+	client.Client
+	ctx context.Context
+}
+
+func (c *ctxClient) Call(result interface{}, method string, args ...interface{}) error {
+	return c.CallContext(c.ctx, result, method, args...)
+}
+
+func (c *ctxClient) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if cl, ok := c.Client.(contextCaller); ok {
+		return cl.CallContext(ctx, result, method, args...)
+	}
+
+	return c.Client.Call(result, method, args...)
+}
+
+// Subscribe sets up the subscription within the client context. ctx is the setup
+// context of the caller, its deadline is kept if set.
+func (c *ctxClient) Subscribe(ctx context.Context, namespace, subscribeMethodSuffix, unsubscribeMethodSuffix,
+	notificationMethodSuffix string, channel interface{}, args ...interface{}) (*gethrpc.ClientSubscription, error) {
+	sctx, cancel := context.WithCancel(c.ctx)
+	if deadline, ok := ctx.Deadline(); ok {
+		sctx, cancel = context.WithDeadline(c.ctx, deadline)
+	}
+	defer cancel()
+
+	return c.Client.Subscribe(sctx, namespace, subscribeMethodSuffix, unsubscribeMethodSuffix, notificationMethodSuffix, channel, args...)
+}
+
+// withContext returns a connection where all rpc calls and subscriptions
+// are bound to ctx. The returned connection shares the underlying websocket
+// with cl and must not be closed.
+func withContext(ctx context.Context, cl Conn) Conn {
+	if ctx.Done() == nil {
+		// can't be canceled, nothing to bind
+		return cl
+	}
+
+	inner := cl.Client
+	if bound, ok := inner.(*ctxClient); ok {
+		inner = bound.Client
+	}
+
+	bound := &ctxClient{Client: inner, ctx: ctx}
+	return &gsrpc.SubstrateAPI{
+		RPC:    newRPC(bound),
+		Client: bound,
+	}
+}
+
+// getClient is like GetClient but the returned connection is bound to ctx
+func (s *Substrate) getClient(ctx context.Context) (Conn, Meta, error) {
+	cl, meta, err := s.GetClient()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return withContext(ctx, cl), meta, nil
+}
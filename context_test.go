@@ -0,0 +1,72 @@
+package substrate
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContextCanceled(t *testing.T) {
+	node := newFakeNode(t)
+
+	cl := testClient(t, node, DefaultManagerOptions())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := cl.TimeCtx(ctx)
+	require.True(t, errors.Is(err, context.Canceled))
+
+	// the connection is still usable with other contexts
+	_, err = cl.Time()
+	require.NoError(t, err)
+}
+
+func TestContextDeadlineOnStuckNode(t *testing.T) {
+	node := newFakeNode(t)
+
+	cl := testClient(t, node, DefaultManagerOptions())
+
+	release := make(chan struct{})
+	defer close(release)
+	node.handle("state_getStorage", func(params []json.RawMessage) (interface{}, error) {
+		<-release
+		return nil, nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	started := time.Now()
+	_, err := cl.TimeCtx(ctx)
+	require.True(t, errors.Is(err, context.DeadlineExceeded))
+	require.Less(t, time.Since(started), 5*time.Second)
+}
+
+func TestCallOnceDeadline(t *testing.T) {
+	node := newFakeNode(t)
+	// extrinsic is accepted but never included in a block
+	node.subscription("author_submitAndWatchExtrinsic", "author_unwatchExtrinsic")
+
+	identity, err := NewIdentityFromSr25519Phrase("//Alice")
+	require.NoError(t, err)
+
+	sub := testClient(t, node, DefaultManagerOptions())
+
+	cl, meta, err := sub.GetClient()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	started := time.Now()
+	_, err = sub.CallOnceCtx(ctx, cl, meta, identity, types.Call{})
+	require.True(t, errors.Is(err, context.DeadlineExceeded), "unexpected error: %v", err)
+	require.Less(t, time.Since(started), 5*time.Second)
+	require.Equal(t, 1, node.count("author_submitAndWatchExtrinsic"))
+}
@@ -1,6 +1,7 @@
 package substrate
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
@@ -206,7 +207,12 @@ type Contract struct {
 
 // CreateNodeContract creates a contract for deployment
 func (s *Substrate) CreateNodeContract(identity Identity, node uint32, body string, hash string, publicIPs uint32, solutionProviderID *uint64) (uint64, error) {
-	cl, meta, err := s.GetClient()
+	return s.CreateNodeContractCtx(context.Background(), identity, node, body, hash, publicIPs, solutionProviderID)
+}
+
+// CreateNodeContractCtx is like CreateNodeContract but takes a context
+func (s *Substrate) CreateNodeContractCtx(ctx context.Context, identity Identity, node uint32, body string, hash string, publicIPs uint32, solutionProviderID *uint64) (uint64, error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -225,17 +231,22 @@ func (s *Substrate) CreateNodeContract(identity Identity, node uint32, body stri
 		return 0, errors.Wrap(err, "failed to create call")
 	}
 
-	_, err = s.Call(cl, meta, identity, c)
+	_, err = s.CallCtx(ctx, cl, meta, identity, c)
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to create contract")
 	}
 
-	return s.GetContractWithHash(node, h)
+	return s.GetContractWithHashCtx(ctx, node, h)
 }
 
 // CreateNameContract creates a contract for deployment
 func (s *Substrate) CreateNameContract(identity Identity, name string) (uint64, error) {
-	cl, meta, err := s.GetClient()
+	return s.CreateNameContractCtx(context.Background(), identity, name)
+}
+
+// CreateNameContractCtx is like CreateNameContract but takes a context
+func (s *Substrate) CreateNameContractCtx(ctx context.Context, identity Identity, name string) (uint64, error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -248,17 +259,22 @@ func (s *Substrate) CreateNameContract(identity Identity, name string) (uint64,
 		return 0, errors.Wrap(err, "failed to create call")
 	}
 
-	_, err = s.Call(cl, meta, identity, c)
+	_, err = s.CallCtx(ctx, cl, meta, identity, c)
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to create contract")
 	}
 
-	return s.GetContractIDByNameRegistration(name)
+	return s.GetContractIDByNameRegistrationCtx(ctx, name)
 }
 
 // CreateRentContract creates a rent contract on a node
 func (s *Substrate) CreateRentContract(identity Identity, node uint32, solutionProviderID *uint64) (uint64, error) {
-	cl, meta, err := s.GetClient()
+	return s.CreateRentContractCtx(context.Background(), identity, node, solutionProviderID)
+}
+
+// CreateRentContractCtx is like CreateRentContract but takes a context
+func (s *Substrate) CreateRentContractCtx(ctx context.Context, identity Identity, node uint32, solutionProviderID *uint64) (uint64, error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -276,17 +292,22 @@ func (s *Substrate) CreateRentContract(identity Identity, node uint32, solutionP
 		return 0, errors.Wrap(err, "failed to create call")
 	}
 
-	_, err = s.Call(cl, meta, identity, c)
+	_, err = s.CallCtx(ctx, cl, meta, identity, c)
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to create rent contract")
 	}
 
-	return s.GetNodeRentContract(node)
+	return s.GetNodeRentContractCtx(ctx, node)
 }
 
 // UpdateNodeContract updates existing contract
 func (s *Substrate) UpdateNodeContract(identity Identity, contract uint64, body string, hash string) (uint64, error) {
-	cl, meta, err := s.GetClient()
+	return s.UpdateNodeContractCtx(context.Background(), identity, contract, body, hash)
+}
+
+// UpdateNodeContractCtx is like UpdateNodeContract but takes a context
+func (s *Substrate) UpdateNodeContractCtx(ctx context.Context, identity Identity, contract uint64, body string, hash string) (uint64, error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -300,7 +321,7 @@ func (s *Substrate) UpdateNodeContract(identity Identity, contract uint64, body
 		return 0, errors.Wrap(err, "failed to create call")
 	}
 
-	_, err = s.Call(cl, meta, identity, c)
+	_, err = s.CallCtx(ctx, cl, meta, identity, c)
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to update contract")
 	}
@@ -310,7 +331,12 @@ func (s *Substrate) UpdateNodeContract(identity Identity, contract uint64, body
 
 // CancelContract creates a contract for deployment
 func (s *Substrate) CancelContract(identity Identity, contract uint64) error {
-	cl, meta, err := s.GetClient()
+	return s.CancelContractCtx(context.Background(), identity, contract)
+}
+
+// CancelContractCtx is like CancelContract but takes a context
+func (s *Substrate) CancelContractCtx(ctx context.Context, identity Identity, contract uint64) error {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return err
 	}
@@ -321,7 +347,7 @@ func (s *Substrate) CancelContract(identity Identity, contract uint64) error {
 		return errors.Wrap(err, "failed to cancel call")
 	}
 
-	_, err = s.Call(cl, meta, identity, c)
+	_, err = s.CallCtx(ctx, cl, meta, identity, c)
 	if err != nil {
 		return errors.Wrap(err, "failed to cancel contract")
 	}
@@ -332,7 +358,12 @@ func (s *Substrate) CancelContract(identity Identity, contract uint64) error {
 // SetContractConsumption can only be called by the node that owns the contract to set the used
 // resources associated with the node.
 func (s *Substrate) SetContractConsumption(identity Identity, resources ...ContractResources) error {
-	cl, meta, err := s.GetClient()
+	return s.SetContractConsumptionCtx(context.Background(), identity, resources...)
+}
+
+// SetContractConsumptionCtx is like SetContractConsumption but takes a context
+func (s *Substrate) SetContractConsumptionCtx(ctx context.Context, identity Identity, resources ...ContractResources) error {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return err
 	}
@@ -345,7 +376,7 @@ func (s *Substrate) SetContractConsumption(identity Identity, resources ...Contr
 		return errors.Wrap(err, "failed to create call")
 	}
 
-	_, err = s.Call(cl, meta, identity, c)
+	_, err = s.CallCtx(ctx, cl, meta, identity, c)
 	if err != nil {
 		return errors.Wrap(err, "failed to set contract used resources")
 	}
@@ -355,7 +386,12 @@ func (s *Substrate) SetContractConsumption(identity Identity, resources ...Contr
 
 // GetContract we should not have calls to create contract, instead only get
 func (s *Substrate) GetContract(id uint64) (*Contract, error) {
-	cl, meta, err := s.GetClient()
+	return s.GetContractCtx(context.Background(), id)
+}
+
+// GetContractCtx is like GetContract but takes a context
+func (s *Substrate) GetContractCtx(ctx context.Context, id uint64) (*Contract, error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -375,7 +411,12 @@ func (s *Substrate) GetContract(id uint64) (*Contract, error) {
 
 // GetContractWithHash gets a contract given the node id and hash
 func (s *Substrate) GetContractWithHash(node uint32, hash HexHash) (uint64, error) {
-	cl, meta, err := s.GetClient()
+	return s.GetContractWithHashCtx(context.Background(), node, hash)
+}
+
+// GetContractWithHashCtx is like GetContractWithHash but takes a context
+func (s *Substrate) GetContractWithHashCtx(ctx context.Context, node uint32, hash HexHash) (uint64, error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -393,7 +434,7 @@ func (s *Substrate) GetContractWithHash(node uint32, hash HexHash) (uint64, erro
 		return 0, errors.Wrap(err, "failed to create substrate query key")
 	}
 	var contract types.U64
-	_, err = cl.RPC.State.GetStorageLatest(key, &contract)
+	_, err = s.getStorage(cl, key, &contract)
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to lookup contracts")
 	}
@@ -407,7 +448,12 @@ func (s *Substrate) GetContractWithHash(node uint32, hash HexHash) (uint64, erro
 
 // GetContractIDByNameRegistration gets a contract given the its name
 func (s *Substrate) GetContractIDByNameRegistration(name string) (uint64, error) {
-	cl, meta, err := s.GetClient()
+	return s.GetContractIDByNameRegistrationCtx(context.Background(), name)
+}
+
+// GetContractIDByNameRegistrationCtx is like GetContractIDByNameRegistration but takes a context
+func (s *Substrate) GetContractIDByNameRegistrationCtx(ctx context.Context, name string) (uint64, error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -421,7 +467,7 @@ func (s *Substrate) GetContractIDByNameRegistration(name string) (uint64, error)
 		return 0, errors.Wrap(err, "failed to create substrate query key")
 	}
 	var contract types.U64
-	_, err = cl.RPC.State.GetStorageLatest(key, &contract)
+	_, err = s.getStorage(cl, key, &contract)
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to lookup contracts")
 	}
@@ -435,7 +481,12 @@ func (s *Substrate) GetContractIDByNameRegistration(name string) (uint64, error)
 
 // GetNodeContracts gets all contracts on a node (pk) in given state
 func (s *Substrate) GetNodeContracts(node uint32) ([]types.U64, error) {
-	cl, meta, err := s.GetClient()
+	return s.GetNodeContractsCtx(context.Background(), node)
+}
+
+// GetNodeContractsCtx is like GetNodeContracts but takes a context
+func (s *Substrate) GetNodeContractsCtx(ctx context.Context, node uint32) ([]types.U64, error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -450,7 +501,7 @@ func (s *Substrate) GetNodeContracts(node uint32) ([]types.U64, error) {
 		return nil, errors.Wrap(err, "failed to create substrate query key")
 	}
 	var contracts []types.U64
-	_, err = cl.RPC.State.GetStorageLatest(key, &contracts)
+	_, err = s.getStorage(cl, key, &contracts)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to lookup contracts")
 	}
@@ -460,7 +511,12 @@ func (s *Substrate) GetNodeContracts(node uint32) ([]types.U64, error) {
 
 // GetNodeContracts gets all contracts on a node (pk) in given state
 func (s *Substrate) GetNodeRentContract(node uint32) (uint64, error) {
-	cl, meta, err := s.GetClient()
+	return s.GetNodeRentContractCtx(context.Background(), node)
+}
+
+// GetNodeRentContractCtx is like GetNodeRentContract but takes a context
+func (s *Substrate) GetNodeRentContractCtx(ctx context.Context, node uint32) (uint64, error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -475,7 +531,7 @@ func (s *Substrate) GetNodeRentContract(node uint32) (uint64, error) {
 		return 0, errors.Wrap(err, "failed to create substrate query key")
 	}
 
-	raw, err := cl.RPC.State.GetStorageRawLatest(key)
+	raw, err := s.getStorageRaw(cl, key)
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to lookup contract")
 	}
@@ -490,7 +546,7 @@ func (s *Substrate) GetNodeRentContract(node uint32) (uint64, error) {
 }
 
 func (s *Substrate) getContract(cl Conn, key types.StorageKey) (*Contract, error) {
-	raw, err := cl.RPC.State.GetStorageRawLatest(key)
+	raw, err := s.getStorageRaw(cl, key)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to lookup contract")
 	}
@@ -533,7 +589,12 @@ func (s *NruConsumption) IsEmpty() bool {
 
 // Report send a capacity report to substrate
 func (s *Substrate) Report(identity Identity, consumptions []NruConsumption) (types.Hash, error) {
-	cl, meta, err := s.GetClient()
+	return s.ReportCtx(context.Background(), identity, consumptions)
+}
+
+// ReportCtx is like Report but takes a context
+func (s *Substrate) ReportCtx(ctx context.Context, identity Identity, consumptions []NruConsumption) (types.Hash, error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return types.Hash{}, err
 	}
@@ -543,7 +604,7 @@ func (s *Substrate) Report(identity Identity, consumptions []NruConsumption) (ty
 		return types.Hash{}, errors.Wrap(err, "failed to create call")
 	}
 
-	callResponse, err := s.Call(cl, meta, identity, c)
+	callResponse, err := s.CallCtx(ctx, cl, meta, identity, c)
 	if err != nil {
 		return types.Hash{}, errors.Wrap(err, "failed to create report")
 	}
@@ -555,3 +616,42 @@ type ContractResources struct {
 	ContractID types.U64
 	Used       Resources
 }
+
+// ScannedContract is a contract found by ScanContracts
+type ScannedContract struct {
+	ID       uint64
+	Contract Contract
+	Err      error
+}
+
+// ScanContracts is like ScanNodes but gets contracts
+func (s *Substrate) ScanContracts(ctx context.Context, from, to uint64, opts ...RangeOption) (<-chan ScannedContract, error) {
+	entries, err := s.scan(ctx, "SmartContractModule", "Contracts", uint64(from), uint64(to), encodeU64, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan ScannedContract)
+	go func() {
+		defer close(ch)
+
+		for entry := range entries {
+			scanned := ScannedContract{ID: uint64(entry.id), Err: entry.err}
+			if errors.Is(scanned.Err, ErrNotFound) {
+				scanned.Err = errors.Wrap(ErrNotFound, "contract not found")
+			} else if scanned.Err == nil {
+				if err := types.Decode(entry.value, &scanned.Contract); err != nil {
+					scanned.Err = errors.Wrap(err, "failed to load object")
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case ch <- scanned:
+			}
+		}
+	}()
+
+	return ch, nil
+}
@@ -1,6 +1,7 @@
 package substrate
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
@@ -10,7 +11,12 @@ import (
 var ErrDepositFeeNotFound = fmt.Errorf("deposit fee not found")
 
 func (s *Substrate) GetDepositFee() (int64, error) {
-	cl, meta, err := s.GetClient()
+	return s.GetDepositFeeCtx(context.Background())
+}
+
+// GetDepositFeeCtx is like GetDepositFee but takes a context
+func (s *Substrate) GetDepositFeeCtx(ctx context.Context) (int64, error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -22,7 +28,7 @@ func (s *Substrate) GetDepositFee() (int64, error) {
 		return 0, err
 	}
 
-	ok, err := cl.RPC.State.GetStorageLatest(key, &fee)
+	ok, err := s.getStorage(cl, key, &fee)
 	if err != nil {
 		return 0, err
 	}
@@ -0,0 +1,289 @@
+package substrate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// ErrInvalidTransaction is returned by a dry run if the extrinsic
+	// can't be included in a block at all
+	ErrInvalidTransaction = fmt.Errorf("invalid transaction")
+)
+
+// InvalidTransaction reasons as defined by substrate
+var invalidTransactionErrors = []string{
+	"Call",
+	"Payment",
+	"Future",
+	"Stale",
+	"BadProof",
+	"AncientBirthBlock",
+	"ExhaustsResources",
+	"Custom",
+	"BadMandatory",
+	"MandatoryValidation",
+	"BadSigner",
+}
+
+// UnknownTransaction reasons as defined by substrate
+var unknownTransactionErrors = []string{
+	"CannotLookup",
+	"NoUnsignedValidator",
+	"Custom",
+}
+
+// FeeInfo is the fee estimation of an extrinsic
+type FeeInfo struct {
+	// Weight is the ref time weight of the extrinsic
+	Weight uint64
+	// Class is the dispatch class of the extrinsic
+	Class string
+	// PartialFee is the fee to pay for the extrinsic inclusion, without the tip
+	PartialFee *big.Int
+}
+
+type feeInfo struct {
+	Weight     json.RawMessage `json:"weight"`
+	Class      string          `json:"class"`
+	PartialFee json.RawMessage `json:"partialFee"`
+}
+
+// EstimateFee estimates the fee of submitting call by identity. The call is also
+// dry run, and the returned error is the reason the call would fail if any. Nodes
+// that don't allow unsafe rpc methods, like the public TFChain nodes, can't dry
+// run calls, then the fee is returned without error. Other errors of the dry run,
+// like a canceled context, are returned with the fee
+func (s *Substrate) EstimateFee(identity Identity, call types.Call) (FeeInfo, error) {
+	return s.EstimateFeeCtx(context.Background(), identity, call)
+}
+
+// EstimateFeeCtx is like EstimateFee but takes a context
+func (s *Substrate) EstimateFeeCtx(ctx context.Context, identity Identity, call types.Call) (info FeeInfo, err error) {
+	cl, meta, err := s.getClient(ctx)
+	if err != nil {
+		return info, err
+	}
+
+	ext, err := s.dryRunExtrinsic(cl, meta, identity, call, CallOptions{})
+	if err != nil {
+		return info, err
+	}
+
+	encoded, err := types.EncodeToHex(ext)
+	if err != nil {
+		return info, errors.Wrap(err, "failed to encode extrinsic")
+	}
+
+	var raw feeInfo
+	if err := cl.Client.Call(&raw, "payment_queryInfo", encoded); err != nil {
+		return info, errors.Wrap(err, "failed to query extrinsic fee")
+	}
+
+	info.Class = raw.Class
+	if info.Weight, err = decodeWeight(raw.Weight); err != nil {
+		return info, err
+	}
+
+	if info.PartialFee, err = decodeBalance(raw.PartialFee); err != nil {
+		return info, err
+	}
+
+	result, err := systemDryRun(cl, ext)
+	if isMethodNotFound(err) {
+		log.Debug().Err(err).Msg("node can't dry run extrinsics, only estimating fee")
+		return info, nil
+	} else if err != nil {
+		if ctx.Err() != nil {
+			return info, ctx.Err()
+		}
+
+		return info, err
+	}
+
+	return info, applyExtrinsicResult(meta, result)
+}
+
+// DryRun checks if call by identity would succeed at the current best block. The
+// returned error is the reason the call would fail. Nodes only allow dry runs if
+// unsafe rpc methods are enabled.
+func (s *Substrate) DryRun(identity Identity, call types.Call) error {
+	return s.DryRunCtx(context.Background(), identity, call)
+}
+
+// DryRunCtx is like DryRun but takes a context
+func (s *Substrate) DryRunCtx(ctx context.Context, identity Identity, call types.Call) error {
+	cl, meta, err := s.getClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	ext, err := s.dryRunExtrinsic(cl, meta, identity, call, CallOptions{})
+	if err != nil {
+		return err
+	}
+
+	return s.dryRun(cl, meta, ext)
+}
+
+// dryRunExtrinsic creates the extrinsic of a call to be dry run. The extrinsic
+// uses the account nonce of the best block state, since that's the state the
+// extrinsic is applied to.
+func (s *Substrate) dryRunExtrinsic(cl Conn, meta Meta, identity Identity, call types.Call, options CallOptions) (types.Extrinsic, error) {
+	var nonce uint64
+	account, err := s.getAccount(cl, meta, identity)
+	if err == nil {
+		nonce = uint64(account.Nonce)
+	} else if !errors.Is(err, ErrAccountNotFound) {
+		return types.Extrinsic{}, errors.Wrap(err, "failed to get account")
+	}
+
+	return s.extrinsic(cl, identity, call, nonce, options)
+}
+
+func (s *Substrate) dryRun(cl Conn, meta Meta, ext types.Extrinsic) error {
+	result, err := systemDryRun(cl, ext)
+	if err != nil {
+		return err
+	}
+
+	return applyExtrinsicResult(meta, result)
+}
+
+// systemDryRun gets the scale encoded result of a system_dryRun call
+func systemDryRun(cl Conn, ext types.Extrinsic) ([]byte, error) {
+	encoded, err := types.EncodeToHex(ext)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode extrinsic")
+	}
+
+	var result string
+	if err := cl.Client.Call(&result, "system_dryRun", encoded); err != nil {
+		return nil, errors.Wrap(err, "failed to dry run extrinsic")
+	}
+
+	data, err := types.HexDecodeString(result)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid dry run result")
+	}
+
+	return data, nil
+}
+
+// rpcMethodNotFound is the code of the error nodes return for methods they don't
+// have, and for unsafe methods if they don't allow them
+const rpcMethodNotFound = -32601
+
+// isMethodNotFound checks err is the error of a call to a method the node
+// doesn't have or allow
+func isMethodNotFound(err error) bool {
+	var rpcErr interface{ ErrorCode() int }
+	return errors.As(err, &rpcErr) && rpcErr.ErrorCode() == rpcMethodNotFound
+}
+
+// applyExtrinsicResult decodes the scale encoded
+// Result<Result<(), DispatchError>, TransactionValidityError>
+// into the error of the extrinsic if any
+func applyExtrinsicResult(meta Meta, data []byte) error {
+	decoder := scale.NewDecoder(bytes.NewReader(data))
+
+	valid, err := decoder.ReadOneByte()
+	if err != nil {
+		return errors.Wrap(err, "failed to decode dry run result")
+	}
+
+	if valid == 0 {
+		dispatched, err := decoder.ReadOneByte()
+		if err != nil {
+			return errors.Wrap(err, "failed to decode dry run result")
+		}
+
+		if dispatched == 0 {
+			return nil
+		}
+
+		var dispatchErr types.DispatchError
+		if err := decoder.Decode(&dispatchErr); err != nil {
+			return errors.Wrap(err, "failed to decode dispatch error")
+		}
+
+		return dispatchError(meta, dispatchErr)
+	}
+
+	kind, err := decoder.ReadOneByte()
+	if err != nil {
+		return errors.Wrap(err, "failed to decode transaction validity error")
+	}
+
+	reason, err := decoder.ReadOneByte()
+	if err != nil {
+		return errors.Wrap(err, "failed to decode transaction validity error")
+	}
+
+	reasons := invalidTransactionErrors
+	if kind != 0 {
+		reasons = unknownTransactionErrors
+	}
+
+	if int(reason) >= len(reasons) {
+		return errors.Wrapf(ErrInvalidTransaction, "unknown reason (%d)", reason)
+	}
+
+	return errors.Wrap(ErrInvalidTransaction, reasons[reason])
+}
+
+// decodeWeight decodes the weight returned by payment_queryInfo. The
+// weight is either a number or a struct with the ref time
+func decodeWeight(raw json.RawMessage) (uint64, error) {
+	if len(raw) == 0 {
+		return 0, nil
+	}
+
+	var weight uint64
+	if err := json.Unmarshal(raw, &weight); err == nil {
+		return weight, nil
+	}
+
+	var weightV2 struct {
+		RefTime      *uint64 `json:"refTime"`
+		RefTimeSnake *uint64 `json:"ref_time"`
+	}
+	if err := json.Unmarshal(raw, &weightV2); err != nil {
+		return 0, errors.Wrap(err, "failed to decode extrinsic weight")
+	}
+
+	if weightV2.RefTime != nil {
+		return *weightV2.RefTime, nil
+	} else if weightV2.RefTimeSnake != nil {
+		return *weightV2.RefTimeSnake, nil
+	}
+
+	return 0, nil
+}
+
+// decodeBalance decodes a balance returned as a json number or string
+func decodeBalance(raw json.RawMessage) (*big.Int, error) {
+	value := strings.Trim(string(raw), `"`)
+
+	base := 10
+	if strings.HasPrefix(value, "0x") {
+		value = strings.TrimPrefix(value, "0x")
+		base = 16
+	}
+
+	balance, ok := new(big.Int).SetString(value, base)
+	if !ok {
+		return nil, fmt.Errorf("invalid balance '%s'", string(raw))
+	}
+
+	return balance, nil
+}
@@ -0,0 +1,143 @@
+package substrate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyExtrinsicResult(t *testing.T) {
+	require.NoError(t, applyExtrinsicResult(nil, []byte{0, 0}))
+
+	// SmartContractModule TwinNotExists
+	err := applyExtrinsicResult(nil, []byte{0, 1, 3, 12, 0, 0, 0, 0})
+	require.EqualError(t, err, "TwinNotExists")
+
+	// Invalid(Future)
+	err = applyExtrinsicResult(nil, []byte{1, 0, 2})
+	require.True(t, errors.Is(err, ErrInvalidTransaction))
+	require.Contains(t, err.Error(), "Future")
+
+	// Unknown(CannotLookup)
+	err = applyExtrinsicResult(nil, []byte{1, 1, 0})
+	require.True(t, errors.Is(err, ErrInvalidTransaction))
+	require.Contains(t, err.Error(), "CannotLookup")
+}
+
+// dryRunResult sets the result of system_dryRun on node
+func dryRunResult(node *fakeNode, result []byte) {
+	node.handle("system_dryRun", func(params []json.RawMessage) (interface{}, error) {
+		return types.HexEncodeToString(result), nil
+	})
+}
+
+func TestDryRun(t *testing.T) {
+	node := newFakeNode(t)
+	sub, identity := callTestClient(t, node)
+
+	dryRunResult(node, []byte{0, 0})
+	require.NoError(t, sub.DryRun(identity, types.Call{}))
+
+	dryRunResult(node, []byte{0, 1, 3, 12, 0, 0, 0, 0})
+	// errors are resolved from the node metadata
+	require.EqualError(t, sub.DryRun(identity, types.Call{}), "ValueLow")
+}
+
+func TestEstimateFee(t *testing.T) {
+	node := newFakeNode(t)
+	node.handle("payment_queryInfo", func(params []json.RawMessage) (interface{}, error) {
+		return map[string]interface{}{
+			"weight":     map[string]interface{}{"refTime": 1000, "proofSize": 0},
+			"class":      "normal",
+			"partialFee": "123456789",
+		}, nil
+	})
+	dryRunResult(node, []byte{0, 0})
+
+	sub, identity := callTestClient(t, node)
+
+	fee, err := sub.EstimateFee(identity, types.Call{})
+	require.NoError(t, err)
+	require.EqualValues(t, 1000, fee.Weight)
+	require.Equal(t, "normal", fee.Class)
+	require.Equal(t, "123456789", fee.PartialFee.String())
+
+	// the fee is still returned if the call would fail
+	dryRunResult(node, []byte{0, 1, 3, 12, 0, 0, 0, 0})
+	fee, err = sub.EstimateFee(identity, types.Call{})
+	require.EqualError(t, err, "ValueLow")
+	require.Equal(t, "123456789", fee.PartialFee.String())
+}
+
+func TestEstimateFeeWithoutDryRun(t *testing.T) {
+	node := newFakeNode(t)
+	node.handle("payment_queryInfo", func(params []json.RawMessage) (interface{}, error) {
+		return map[string]interface{}{
+			"weight":     1000,
+			"class":      "normal",
+			"partialFee": "123456789",
+		}, nil
+	})
+
+	sub, identity := callTestClient(t, node)
+
+	// system_dryRun is not served, like on nodes without unsafe rpc methods
+	fee, err := sub.EstimateFee(identity, types.Call{})
+	require.NoError(t, err)
+	require.Equal(t, "123456789", fee.PartialFee.String())
+	require.Equal(t, 1, node.count("system_dryRun"))
+
+	require.Error(t, sub.DryRun(identity, types.Call{}))
+}
+
+func TestEstimateFeeDryRunErrors(t *testing.T) {
+	node := newFakeNode(t)
+	node.handle("payment_queryInfo", func(params []json.RawMessage) (interface{}, error) {
+		return map[string]interface{}{
+			"weight":     1000,
+			"class":      "normal",
+			"partialFee": "123456789",
+		}, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	node.handle("system_dryRun", func(params []json.RawMessage) (interface{}, error) {
+		return nil, fmt.Errorf("state already discarded")
+	})
+
+	sub, identity := callTestClient(t, node)
+
+	// only a node without system_dryRun falls back to the fee alone
+	fee, err := sub.EstimateFeeCtx(ctx, identity, types.Call{})
+	require.EqualError(t, err, "failed to dry run extrinsic: state already discarded")
+	require.Equal(t, "123456789", fee.PartialFee.String())
+
+	node.handle("system_dryRun", func(params []json.RawMessage) (interface{}, error) {
+		cancel()
+		return nil, fmt.Errorf("interrupted")
+	})
+
+	_, err = sub.EstimateFeeCtx(ctx, identity, types.Call{})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestCallDryRunFirst(t *testing.T) {
+	node := newCallTestNode(t)
+	dryRunResult(node, []byte{0, 1, 3, 12, 0, 0, 0, 0})
+
+	sub, identity := callTestClient(t, node)
+	cl, meta, err := sub.GetClient()
+	require.NoError(t, err)
+
+	_, err = sub.Call(cl, meta, identity, types.Call{}, WithDryRunFirst())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "ValueLow")
+	require.Equal(t, 0, node.count("author_submitAndWatchExtrinsic"))
+}
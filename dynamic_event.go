@@ -0,0 +1,312 @@
+package substrate
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/pkg/errors"
+)
+
+// DynamicEvent is an event that has no field in EventRecords, like events of
+// new pallets or new variants. It is decoded with the type information of
+// the runtime metadata.
+//
+// Fields are keyed by field name, or by position for unnamed fields. Values
+// are decoded as:
+//   - bool, string, uint8-uint64 and int8-int64 for primitives, *big.Int for
+//     128 and 256 bits integers and compact values
+//   - []byte for sequences and arrays of bytes and bit sequences
+//   - []interface{} for other sequences, arrays and tuples
+//   - map[string]interface{} for structs, a struct with a single unnamed
+//     field is decoded as its field
+//   - the variant name for enum variants without fields, otherwise a map of
+//     the variant name to its fields
+type DynamicEvent struct {
+	Phase  types.Phase
+	Pallet string
+	Name   string
+	Fields map[string]interface{}
+	Topics []types.Hash
+}
+
+// decodeDynamicEvent decodes the fields of the event with id from decoder
+func decodeDynamicEvent(meta Meta, decoder *scale.Decoder, id types.EventID) (map[string]interface{}, error) {
+	if meta.Version != 14 {
+		return nil, fmt.Errorf("dynamic decoding requires metadata v14, got v%d", meta.Version)
+	}
+
+	for _, pallet := range meta.AsMetadataV14.Pallets {
+		if !pallet.HasEvents || uint8(pallet.Index) != id[0] {
+			continue
+		}
+
+		typ, err := lookupType(meta, pallet.Events.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		if !typ.Def.IsVariant {
+			return nil, fmt.Errorf("events type of pallet %s is not an enum", pallet.Name)
+		}
+
+		for _, variant := range typ.Def.Variant.Variants {
+			if uint8(variant.Index) == id[1] {
+				return decodeFields(meta, decoder, variant.Fields)
+			}
+		}
+
+		return nil, fmt.Errorf("event %d not found in pallet %s", id[1], pallet.Name)
+	}
+
+	return nil, fmt.Errorf("pallet %d not found in metadata", id[0])
+}
+
+func lookupType(meta Meta, id types.Si1LookupTypeID) (*types.Si1Type, error) {
+	typ, ok := meta.AsMetadataV14.EfficientLookup[id.Int64()]
+	if !ok {
+		return nil, fmt.Errorf("type %d not found in metadata", id.Int64())
+	}
+
+	return typ, nil
+}
+
+// decodeFields decodes struct or variant fields into a map
+func decodeFields(meta Meta, decoder *scale.Decoder, fields []types.Si1Field) (map[string]interface{}, error) {
+	values := make(map[string]interface{}, len(fields))
+	for i, field := range fields {
+		name := strconv.Itoa(i)
+		if field.HasName {
+			name = string(field.Name)
+		}
+
+		value, err := decodeType(meta, decoder, field.Type)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode field %s", name)
+		}
+
+		values[name] = value
+	}
+
+	return values, nil
+}
+
+// decodeType decodes a value of the metadata type id
+func decodeType(meta Meta, decoder *scale.Decoder, id types.Si1LookupTypeID) (interface{}, error) {
+	typ, err := lookupType(meta, id)
+	if err != nil {
+		return nil, err
+	}
+
+	def := typ.Def
+	switch {
+	case def.IsComposite:
+		fields := def.Composite.Fields
+		if len(fields) == 1 && !fields[0].HasName {
+			return decodeType(meta, decoder, fields[0].Type)
+		}
+
+		return decodeFields(meta, decoder, fields)
+	case def.IsVariant:
+		index, err := decoder.ReadOneByte()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, variant := range def.Variant.Variants {
+			if uint8(variant.Index) != index {
+				continue
+			}
+
+			if len(variant.Fields) == 0 {
+				return string(variant.Name), nil
+			}
+
+			fields, err := decodeFields(meta, decoder, variant.Fields)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to decode variant %s", variant.Name)
+			}
+
+			return map[string]interface{}{string(variant.Name): fields}, nil
+		}
+
+		return nil, fmt.Errorf("variant %d of type %d not found", index, id.Int64())
+	case def.IsSequence:
+		n, err := decoder.DecodeUintCompact()
+		if err != nil {
+			return nil, err
+		}
+
+		return decodeItems(meta, decoder, def.Sequence.Type, n.Uint64())
+	case def.IsArray:
+		return decodeItems(meta, decoder, def.Array.Type, uint64(def.Array.Len))
+	case def.IsTuple:
+		values := make([]interface{}, 0, len(def.Tuple))
+		for _, item := range def.Tuple {
+			value, err := decodeType(meta, decoder, item)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+
+		return values, nil
+	case def.IsPrimitive:
+		return decodePrimitive(decoder, def.Primitive.Si0TypeDefPrimitive)
+	case def.IsCompact:
+		return decoder.DecodeUintCompact()
+	case def.IsBitSequence:
+		return decodeBitSequence(meta, decoder, def.BitSequence)
+	}
+
+	return nil, fmt.Errorf("unsupported definition of type %d", id.Int64())
+}
+
+// decodeItems decodes n items of type id, bytes are decoded as a []byte
+func decodeItems(meta Meta, decoder *scale.Decoder, id types.Si1LookupTypeID, n uint64) (interface{}, error) {
+	typ, err := lookupType(meta, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if typ.Def.IsPrimitive && typ.Def.Primitive.Si0TypeDefPrimitive == types.IsU8 {
+		return readBytes(decoder, n)
+	}
+
+	var values []interface{}
+	for i := uint64(0); i < n; i++ {
+		value, err := decodeType(meta, decoder, id)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode item %d", i)
+		}
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+// readBytes reads n bytes without allocating them all upfront, so a corrupt
+// length fails with the end of the data instead of a huge allocation
+func readBytes(decoder *scale.Decoder, n uint64) ([]byte, error) {
+	var (
+		data  []byte
+		chunk [1024]byte
+	)
+
+	for n > 0 {
+		size := uint64(len(chunk))
+		if n < size {
+			size = n
+		}
+
+		if err := decoder.Read(chunk[:size]); err != nil {
+			return nil, err
+		}
+
+		data = append(data, chunk[:size]...)
+		n -= size
+	}
+
+	if data == nil {
+		data = []byte{}
+	}
+
+	return data, nil
+}
+
+func decodePrimitive(decoder *scale.Decoder, primitive types.Si0TypeDefPrimitive) (interface{}, error) {
+	var target interface{}
+	switch primitive {
+	case types.IsBool:
+		target = new(bool)
+	case types.IsChar:
+		var v uint32
+		if err := decoder.Decode(&v); err != nil {
+			return nil, err
+		}
+		return string(rune(v)), nil
+	case types.IsStr:
+		target = new(string)
+	case types.IsU8:
+		target = new(uint8)
+	case types.IsU16:
+		target = new(uint16)
+	case types.IsU32:
+		target = new(uint32)
+	case types.IsU64:
+		target = new(uint64)
+	case types.IsI8:
+		target = new(int8)
+	case types.IsI16:
+		target = new(int16)
+	case types.IsI32:
+		target = new(int32)
+	case types.IsI64:
+		target = new(int64)
+	case types.IsU128:
+		var v types.U128
+		if err := decoder.Decode(&v); err != nil {
+			return nil, err
+		}
+		return v.Int, nil
+	case types.IsU256:
+		var v types.U256
+		if err := decoder.Decode(&v); err != nil {
+			return nil, err
+		}
+		return v.Int, nil
+	case types.IsI128:
+		var v types.I128
+		if err := decoder.Decode(&v); err != nil {
+			return nil, err
+		}
+		return v.Int, nil
+	case types.IsI256:
+		var v types.I256
+		if err := decoder.Decode(&v); err != nil {
+			return nil, err
+		}
+		return v.Int, nil
+	default:
+		return nil, fmt.Errorf("unsupported primitive type %d", primitive)
+	}
+
+	if err := decoder.Decode(target); err != nil {
+		return nil, err
+	}
+
+	return reflect.ValueOf(target).Elem().Interface(), nil
+}
+
+// decodeBitSequence reads the raw bytes of a bit sequence, stored in items
+// of the bit store type
+func decodeBitSequence(meta Meta, decoder *scale.Decoder, def types.Si1TypeDefBitSequence) ([]byte, error) {
+	bits, err := decoder.DecodeUintCompact()
+	if err != nil {
+		return nil, err
+	}
+
+	store, err := lookupType(meta, def.BitStoreType)
+	if err != nil {
+		return nil, err
+	}
+
+	size := uint64(1)
+	if store.Def.IsPrimitive {
+		switch store.Def.Primitive.Si0TypeDefPrimitive {
+		case types.IsU16:
+			size = 2
+		case types.IsU32:
+			size = 4
+		case types.IsU64:
+			size = 8
+		}
+	}
+
+	width := size * 8
+	items := (bits.Uint64() + width - 1) / width
+
+	return readBytes(decoder, items*size)
+}
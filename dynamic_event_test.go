@@ -0,0 +1,88 @@
+package substrate
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/require"
+)
+
+// renameEvent renames an event variant in meta, so it no longer matches an
+// EventRecords field
+func renameEvent(t *testing.T, meta *types.Metadata, pallet, event, name string) {
+	for _, p := range meta.AsMetadataV14.Pallets {
+		if string(p.Name) != pallet {
+			continue
+		}
+
+		variants := meta.AsMetadataV14.EfficientLookup[p.Events.Type.Int64()].Def.Variant.Variants
+		for i := range variants {
+			if string(variants[i].Name) == event {
+				variants[i].Name = types.Text(name)
+				return
+			}
+		}
+	}
+
+	t.Fatalf("event %s.%s not found", pallet, event)
+}
+
+func TestDecodeDynamicEvents(t *testing.T) {
+	var meta types.Metadata
+	require.NoError(t, types.DecodeFromHex(types.MetadataV14Data, &meta))
+
+	renameEvent(t, &meta, "Balances", "Transfer", "Transferred")
+	renameEvent(t, &meta, "Utility", "BatchInterrupted", "Interrupted")
+
+	phase := []byte{0, 1, 0, 0, 0}
+	raw := []byte{3 << 2}
+
+	// Balances.Transferred {from, to, amount}
+	raw = append(raw, phase...)
+	raw = append(raw, 6, 2)
+	raw = append(raw, bytes.Repeat([]byte{1}, 32)...)
+	raw = append(raw, bytes.Repeat([]byte{2}, 32)...)
+	amount, err := types.Encode(types.NewU128(*big.NewInt(1000)))
+	require.NoError(t, err)
+	raw = append(raw, amount...)
+	raw = append(raw, 0)
+
+	// Utility.Interrupted {index: 7, error: BadOrigin}
+	raw = append(raw, phase...)
+	raw = append(raw, 1, 0, 7, 0, 0, 0, 2, 0)
+
+	// Utility.ItemCompleted
+	raw = append(raw, phase...)
+	raw = append(raw, 1, 2, 0)
+
+	var events EventRecords
+	require.NoError(t, decodeEventRecords(&meta, 0, raw, &events))
+	require.Len(t, events.Dynamic, 2)
+	require.Len(t, events.Utility_ItemCompleted, 1)
+	require.Len(t, events.Order, 3)
+
+	transfer := events.Dynamic[0]
+	require.Equal(t, "Balances", transfer.Pallet)
+	require.Equal(t, "Transferred", transfer.Name)
+	require.Equal(t, uint32(1), transfer.Phase.AsApplyExtrinsic)
+	require.Equal(t, bytes.Repeat([]byte{1}, 32), transfer.Fields["from"])
+	require.Equal(t, bytes.Repeat([]byte{2}, 32), transfer.Fields["to"])
+	require.Equal(t, big.NewInt(1000), transfer.Fields["amount"])
+
+	interrupted := events.Dynamic[1]
+	require.Equal(t, uint32(7), interrupted.Fields["index"])
+	require.Equal(t, "BadOrigin", interrupted.Fields["error"])
+
+	require.Equal(t, transfer, events.Event(events.Order[0]))
+	require.Equal(t, "Utility_ItemCompleted", events.Order[2].Name)
+
+	var received []DynamicEvent
+	var handlers EventHandlers
+	handlers.On("Balances_Transferred", func(event interface{}) {
+		received = append(received, event.(DynamicEvent))
+	})
+	handlers.Handle(BlockEvents{Events: &events})
+	require.Equal(t, []DynamicEvent{transfer}, received)
+}
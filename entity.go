@@ -1,6 +1,7 @@
 package substrate
 
 import (
+	"context"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/pkg/errors"
 )
@@ -17,7 +18,12 @@ type Entity struct {
 
 // GetEntity gets a entity with ID
 func (s *Substrate) GetEntity(id uint32) (*Entity, error) {
-	cl, meta, err := s.GetClient()
+	return s.GetEntityCtx(context.Background(), id)
+}
+
+// GetEntityCtx is like GetEntity but takes a context
+func (s *Substrate) GetEntityCtx(ctx context.Context, id uint32) (*Entity, error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -31,7 +37,7 @@ func (s *Substrate) GetEntity(id uint32) (*Entity, error) {
 		return nil, errors.Wrap(err, "failed to create substrate query key")
 	}
 
-	raw, err := cl.RPC.State.GetStorageRawLatest(key)
+	raw, err := s.getStorageRaw(cl, key)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to lookup entity")
 	}
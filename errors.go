@@ -0,0 +1,206 @@
+package substrate
+
+import (
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// DispatchErrorKind is the kind of a dispatch error
+type DispatchErrorKind string
+
+const (
+	DispatchOther             DispatchErrorKind = "Other"
+	DispatchCannotLookup      DispatchErrorKind = "CannotLookup"
+	DispatchBadOrigin         DispatchErrorKind = "BadOrigin"
+	DispatchModule            DispatchErrorKind = "Module"
+	DispatchConsumerRemaining DispatchErrorKind = "ConsumerRemaining"
+	DispatchNoProviders       DispatchErrorKind = "NoProviders"
+	DispatchTooManyConsumers  DispatchErrorKind = "TooManyConsumers"
+	DispatchToken             DispatchErrorKind = "Token"
+	DispatchArithmetic        DispatchErrorKind = "Arithmetic"
+	DispatchTransactional     DispatchErrorKind = "Transactional"
+)
+
+// Dispatch errors that are not returned by a pallet
+var (
+	ErrOther             = &ModuleError{Kind: DispatchOther}
+	ErrCannotLookup      = &ModuleError{Kind: DispatchCannotLookup}
+	ErrBadOrigin         = &ModuleError{Kind: DispatchBadOrigin}
+	ErrConsumerRemaining = &ModuleError{Kind: DispatchConsumerRemaining}
+	ErrNoProviders       = &ModuleError{Kind: DispatchNoProviders}
+	ErrTooManyConsumers  = &ModuleError{Kind: DispatchTooManyConsumers}
+	// ErrToken matches all token errors, like ErrTokenNoFunds
+	ErrToken = &ModuleError{Kind: DispatchToken}
+	// ErrArithmetic matches all arithmetic errors, like ErrArithmeticOverflow
+	ErrArithmetic = &ModuleError{Kind: DispatchArithmetic}
+	// ErrTransactional matches all transactional errors
+	ErrTransactional = &ModuleError{Kind: DispatchTransactional}
+
+	ErrTokenNoFunds              = &ModuleError{Kind: DispatchToken, Name: "NoFunds"}
+	ErrTokenWouldDie             = &ModuleError{Kind: DispatchToken, Name: "WouldDie"}
+	ErrTokenBelowMinimum         = &ModuleError{Kind: DispatchToken, Name: "BelowMinimum"}
+	ErrTokenCannotCreate         = &ModuleError{Kind: DispatchToken, Name: "CannotCreate"}
+	ErrTokenUnknownAsset         = &ModuleError{Kind: DispatchToken, Name: "UnknownAsset"}
+	ErrTokenFrozen               = &ModuleError{Kind: DispatchToken, Name: "Frozen"}
+	ErrTokenUnsupported          = &ModuleError{Kind: DispatchToken, Name: "Unsupported"}
+	ErrArithmeticUnderflow       = &ModuleError{Kind: DispatchArithmetic, Name: "Underflow"}
+	ErrArithmeticOverflow        = &ModuleError{Kind: DispatchArithmetic, Name: "Overflow"}
+	ErrArithmeticDivisionByZero  = &ModuleError{Kind: DispatchArithmetic, Name: "DivisionByZero"}
+	ErrTransactionalLimitReached = &ModuleError{Kind: DispatchTransactional, Name: "LimitReached"}
+	ErrTransactionalNoLayer      = &ModuleError{Kind: DispatchTransactional, Name: "NoLayer"}
+)
+
+// ModuleError is an error of a dispatched call. Most errors are returned by a
+// runtime pallet (Kind is DispatchModule), other kinds of errors have no
+// pallet and the Name is only set if the kind has variants (like Token errors)
+type ModuleError struct {
+	// Kind of the dispatch error
+	Kind DispatchErrorKind
+	// Pallet is the name of the pallet that returned the error
+	Pallet string
+	// PalletIndex is the index of the pallet in the runtime
+	PalletIndex uint8
+	// Name of the error, like NodeHasActiveContracts
+	Name string
+	// Index of the error in the pallet errors
+	Index uint32
+	// Docs of the error as found in the runtime metadata
+	Docs string
+}
+
+func (e *ModuleError) Error() string {
+	switch {
+	case e.Kind != DispatchModule && len(e.Name) == 0:
+		return string(e.Kind)
+	case e.Kind != DispatchModule:
+		return fmt.Sprintf("%s error: %s", e.Kind, e.Name)
+	case len(e.Name) == 0:
+		return fmt.Sprintf("unknown module error (%d) with code %d occured", e.PalletIndex, e.Index)
+	default:
+		return e.Name
+	}
+}
+
+// Is implements errors.Is. The target matches if all its set fields (Kind,
+// Pallet and Name) are equal to the error fields
+func (e *ModuleError) Is(target error) bool {
+	t, ok := target.(*ModuleError)
+	if !ok {
+		return false
+	}
+
+	if len(t.Kind) != 0 && t.Kind != e.Kind {
+		return false
+	}
+
+	if len(t.Pallet) != 0 && t.Pallet != e.Pallet {
+		return false
+	}
+
+	return len(t.Name) == 0 || t.Name == e.Name
+}
+
+// dispatchError converts a dispatch error to a *ModuleError
+func dispatchError(meta Meta, e types.DispatchError) error {
+	switch {
+	case e.IsModule:
+		return moduleError(meta, e.ModuleError)
+	case e.IsCannotLookup:
+		return &ModuleError{Kind: DispatchCannotLookup}
+	case e.IsBadOrigin:
+		return &ModuleError{Kind: DispatchBadOrigin}
+	case e.IsConsumerRemaining:
+		return &ModuleError{Kind: DispatchConsumerRemaining}
+	case e.IsNoProviders:
+		return &ModuleError{Kind: DispatchNoProviders}
+	case e.IsTooManyConsumers:
+		return &ModuleError{Kind: DispatchTooManyConsumers}
+	case e.IsToken:
+		return &ModuleError{Kind: DispatchToken, Name: tokenErrorName(e.TokenError)}
+	case e.IsArithmetic:
+		return &ModuleError{Kind: DispatchArithmetic, Name: arithmeticErrorName(e.ArithmeticError)}
+	case e.IsTransactional:
+		return &ModuleError{Kind: DispatchTransactional, Name: transactionalErrorName(e.TransactionalError)}
+	default:
+		return &ModuleError{Kind: DispatchOther}
+	}
+}
+
+func tokenErrorName(e types.TokenError) string {
+	switch {
+	case e.IsNoFunds:
+		return "NoFunds"
+	case e.IsWouldDie:
+		return "WouldDie"
+	case e.IsBelowMinimum:
+		return "BelowMinimum"
+	case e.IsCannotCreate:
+		return "CannotCreate"
+	case e.IsUnknownAsset:
+		return "UnknownAsset"
+	case e.IsFrozen:
+		return "Frozen"
+	case e.IsUnsupported:
+		return "Unsupported"
+	default:
+		return ""
+	}
+}
+
+func arithmeticErrorName(e types.ArithmeticError) string {
+	switch {
+	case e.IsUnderflow:
+		return "Underflow"
+	case e.IsOverflow:
+		return "Overflow"
+	case e.IsDivisionByZero:
+		return "DivisionByZero"
+	default:
+		return ""
+	}
+}
+
+func transactionalErrorName(e types.TransactionalError) string {
+	switch {
+	case e.IsLimitReached:
+		return "LimitReached"
+	case e.IsNoLayer:
+		return "NoLayer"
+	default:
+		return ""
+	}
+}
+
+// moduleError resolves a module error from the runtime metadata. The static
+// module error lists are only used if the metadata doesn't have the error
+func moduleError(meta Meta, e types.ModuleError) *ModuleError {
+	err := &ModuleError{
+		Kind:        DispatchModule,
+		PalletIndex: uint8(e.Index),
+		Index:       uint32(e.Error),
+	}
+
+	if meta != nil && meta.Version == 14 {
+		for _, pallet := range meta.AsMetadataV14.Pallets {
+			if pallet.Index == e.Index {
+				err.Pallet = string(pallet.Name)
+				break
+			}
+		}
+
+		if err.Index <= 0xff {
+			if found, lookupErr := meta.FindError(e.Index, types.U8(err.Index)); lookupErr == nil {
+				err.Name = found.Name
+				err.Docs = found.Value
+				return err
+			}
+		}
+	}
+
+	if int(err.PalletIndex) < len(moduleErrors) && int(err.Index) < len(moduleErrors[err.PalletIndex]) {
+		err.Name = moduleErrors[err.PalletIndex][err.Index]
+	}
+
+	return err
+}
@@ -0,0 +1,81 @@
+package substrate
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModuleErrorFromMetadata(t *testing.T) {
+	var meta types.Metadata
+	require.NoError(t, types.DecodeFromHex(types.MetadataV14Data, &meta))
+
+	err := dispatchError(&meta, types.DispatchError{
+		IsModule:    true,
+		ModuleError: types.ModuleError{Index: 12, Error: 0},
+	})
+
+	var moduleErr *ModuleError
+	require.True(t, errors.As(err, &moduleErr))
+	require.Equal(t, "Democracy", moduleErr.Pallet)
+	require.EqualValues(t, 12, moduleErr.PalletIndex)
+	require.Equal(t, "ValueLow", moduleErr.Name)
+	require.EqualValues(t, 0, moduleErr.Index)
+	require.NotEmpty(t, moduleErr.Docs)
+}
+
+func TestModuleErrorFallback(t *testing.T) {
+	// no metadata, static lists are used
+	err := moduleError(nil, types.ModuleError{Index: 12, Error: 0})
+	require.Equal(t, "TwinNotExists", err.Name)
+	require.EqualError(t, err, "TwinNotExists")
+
+	var meta types.Metadata
+	require.NoError(t, types.DecodeFromHex(types.MetadataV14Data, &meta))
+
+	// pallet is not in metadata nor in the static lists
+	err = moduleError(&meta, types.ModuleError{Index: 60, Error: 1})
+	require.Empty(t, err.Name)
+	require.EqualError(t, err, "unknown module error (60) with code 1 occured")
+}
+
+func TestModuleErrorIs(t *testing.T) {
+	err := errors.Wrap(moduleError(nil, types.ModuleError{Index: 12, Error: 0}), "failed to create contract")
+
+	require.True(t, errors.Is(err, ErrTwinNotExists))
+	require.False(t, errors.Is(err, ErrNodeNotExists))
+	require.True(t, errors.Is(err, &ModuleError{Name: "TwinNotExists"}))
+	// pallet is not known without metadata
+	require.False(t, errors.Is(err, &ModuleError{Pallet: "TfgridModule", Name: "TwinNotExists"}))
+
+	var moduleErr *ModuleError
+	require.True(t, errors.As(err, &moduleErr))
+	require.Equal(t, DispatchModule, moduleErr.Kind)
+	require.EqualValues(t, 12, moduleErr.PalletIndex)
+}
+
+func TestDispatchErrorKinds(t *testing.T) {
+	err := dispatchError(nil, types.DispatchError{IsBadOrigin: true})
+	require.True(t, errors.Is(err, ErrBadOrigin))
+	require.False(t, errors.Is(err, ErrCannotLookup))
+	require.EqualError(t, err, "BadOrigin")
+
+	err = dispatchError(nil, types.DispatchError{IsCannotLookup: true})
+	require.True(t, errors.Is(err, ErrCannotLookup))
+
+	err = dispatchError(nil, types.DispatchError{IsToken: true, TokenError: types.TokenError{IsNoFunds: true}})
+	require.True(t, errors.Is(err, ErrTokenNoFunds))
+	require.True(t, errors.Is(err, ErrToken))
+	require.False(t, errors.Is(err, ErrTokenFrozen))
+	require.False(t, errors.Is(err, ErrArithmetic))
+	require.EqualError(t, err, "Token error: NoFunds")
+
+	err = dispatchError(nil, types.DispatchError{IsArithmetic: true, ArithmeticError: types.ArithmeticError{IsOverflow: true}})
+	require.True(t, errors.Is(err, ErrArithmeticOverflow))
+	require.True(t, errors.Is(err, ErrArithmetic))
+
+	// non module errors never match module errors
+	require.False(t, errors.Is(err, ErrTwinNotExists))
+}
@@ -0,0 +1,198 @@
+package substrate
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/pkg/errors"
+)
+
+// EventRef references a single event in EventRecords
+type EventRef struct {
+	Phase types.Phase
+	// Name of the EventRecords field of the event, like SmartContractModule_ContractCreated
+	Name string
+	// Index of the event in the field slice, or in Dynamic for events
+	// without a field
+	Index int
+}
+
+// Event returns the event referenced by ref, or nil if not found. Events
+// without an EventRecords field are returned as DynamicEvent
+func (e *EventRecords) Event(ref EventRef) interface{} {
+	field := reflect.ValueOf(e).Elem().FieldByName(ref.Name)
+	if !field.IsValid() {
+		if ref.Index < 0 || ref.Index >= len(e.Dynamic) {
+			return nil
+		}
+
+		event := e.Dynamic[ref.Index]
+		if fmt.Sprintf("%s_%s", event.Pallet, event.Name) != ref.Name {
+			return nil
+		}
+
+		return event
+	}
+
+	if field.Kind() != reflect.Slice || ref.Index < 0 || ref.Index >= field.Len() {
+		return nil
+	}
+
+	return field.Index(ref.Index).Interface()
+}
+
+// decodeEventRecords decodes the raw System.Events storage into events. It works
+// like types.EventRecordsRaw.DecodeEventRecords but also keeps the events order
+// in events.Order. Events of spec versions found in DefaultEventRegistry are
+// decoded with the registered type, a zero spec means the latest runtime.
+// Events without an EventRecords field are decoded into events.Dynamic
+func decodeEventRecords(meta Meta, spec uint32, raw types.EventRecordsRaw, events *EventRecords) error {
+	val := reflect.ValueOf(events).Elem()
+	reader := bytes.NewReader(raw)
+	decoder := scale.NewDecoder(reader)
+
+	n, err := decoder.DecodeUintCompact()
+	if err != nil {
+		return errors.Wrap(err, "failed to decode number of events")
+	}
+
+	for i := uint64(0); i < n.Uint64(); i++ {
+		var phase types.Phase
+		if err := decoder.Decode(&phase); err != nil {
+			return errors.Wrapf(err, "unable to decode Phase for event #%d", i)
+		}
+
+		var id types.EventID
+		if err := decoder.Decode(&id); err != nil {
+			return errors.Wrapf(err, "unable to decode EventID for event #%d", i)
+		}
+
+		moduleName, eventName, err := meta.FindEventNamesForEventID(id)
+		if err != nil {
+			return errors.Wrapf(err, "unable to find event with EventID %v in metadata for event #%d", id, i)
+		}
+
+		name := fmt.Sprintf("%s_%s", moduleName, eventName)
+		field := val.FieldByName(name)
+		if !field.IsValid() {
+			event := DynamicEvent{Phase: phase, Pallet: string(moduleName), Name: string(eventName)}
+			if event.Fields, err = decodeDynamicEvent(meta, decoder, id); err != nil {
+				return errors.Wrapf(err, "unable to decode event #%d (%s)", i, name)
+			}
+
+			if err := decoder.Decode(&event.Topics); err != nil {
+				return errors.Wrapf(err, "unable to decode topics of event #%d (%s)", i, name)
+			}
+
+			events.Order = append(events.Order, EventRef{Phase: phase, Name: name, Index: len(events.Dynamic)})
+			events.Dynamic = append(events.Dynamic, event)
+			continue
+		}
+
+		if field.Kind() != reflect.Slice {
+			return fmt.Errorf("unable to find field %s for event #%d with EventID %v", name, i, id)
+		}
+
+		typ := field.Type().Elem()
+		var holder reflect.Value
+		var layouts []EventType
+		if spec != 0 {
+			layouts = DefaultEventRegistry.layouts(spec, string(moduleName), string(eventName))
+		}
+
+		if len(layouts) == 0 {
+			holder = reflect.New(typ).Elem()
+			if err := checkEventType(typ); err != nil {
+				return errors.Wrapf(err, "invalid field %s for event #%d", name, i)
+			}
+
+			if err := decodeEventFields(decoder, holder); err != nil {
+				return errors.Wrapf(err, "unable to decode event #%d (%s)", i, name)
+			}
+		} else {
+			offset := len(raw) - reader.Len()
+			var read int
+			holder, read, err = decodeRegisteredEvent(meta, id, layouts, typ, raw[offset:])
+			if err != nil {
+				return errors.Wrapf(err, "unable to decode event #%d (%s) of spec %d", i, name, spec)
+			}
+
+			if _, err := reader.Seek(int64(offset+read), io.SeekStart); err != nil {
+				return err
+			}
+		}
+
+		holder.Field(0).Set(reflect.ValueOf(phase))
+		topics := holder.Field(holder.NumField() - 1)
+		if err := decoder.Decode(topics.Addr().Interface()); err != nil {
+			return errors.Wrapf(err, "unable to decode topics of event #%d (%s)", i, name)
+		}
+
+		events.Order = append(events.Order, EventRef{Phase: phase, Name: name, Index: field.Len()})
+		field.Set(reflect.Append(field, holder))
+	}
+
+	return nil
+}
+
+// decodeEventFields decodes the fields of an event into holder, an event
+// struct, except the phase and topics
+func decodeEventFields(decoder *scale.Decoder, holder reflect.Value) error {
+	for j := 1; j < holder.NumField()-1; j++ {
+		if err := decoder.Decode(holder.Field(j).Addr().Interface()); err != nil {
+			return errors.Wrapf(err, "unable to decode field %d", j)
+		}
+	}
+
+	return nil
+}
+
+// decodeRegisteredEvent decodes the fields of an event whose layout changed
+// between runtimes from data. The layouts registered for the event are tried
+// in order, then the latest layout. With v14 metadata, the first layout that
+// reads as many bytes as the event fields of the runtime metadata is used,
+// so a wrong spec range doesn't silently misdecode the event. Without type
+// information, the first layout that decodes is used. It returns the event
+// upgraded to the latest layout and the number of bytes read
+func decodeRegisteredEvent(meta Meta, id types.EventID, layouts []EventType, latest reflect.Type, data []byte) (reflect.Value, int, error) {
+	size := -1
+	if meta.Version == 14 {
+		reader := bytes.NewReader(data)
+		if _, err := decodeDynamicEvent(meta, scale.NewDecoder(reader), id); err != nil {
+			return reflect.Value{}, 0, errors.Wrap(err, "failed to decode event with the runtime metadata")
+		}
+		size = len(data) - reader.Len()
+	}
+
+	layouts = append(layouts, EventType{Type: latest})
+	for _, layout := range layouts {
+		holder := reflect.New(layout.Type).Elem()
+		reader := bytes.NewReader(data)
+		if err := decodeEventFields(scale.NewDecoder(reader), holder); err != nil {
+			continue
+		}
+
+		read := len(data) - reader.Len()
+		if size >= 0 && read != size {
+			continue
+		}
+
+		if layout.Upgrade != nil {
+			upgraded := reflect.ValueOf(layout.Upgrade(holder.Interface()))
+			if !upgraded.IsValid() || upgraded.Type() != latest {
+				return reflect.Value{}, 0, fmt.Errorf("upgrade of %v did not return a %v", layout.Type, latest)
+			}
+
+			holder = reflect.New(latest).Elem()
+			holder.Set(upgraded)
+		}
+
+		return holder, read, nil
+	}
+
+	return reflect.Value{}, 0, fmt.Errorf("no layout of the event matches the runtime metadata")
+}
@@ -0,0 +1,452 @@
+package substrate
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// EventFilter selects the events that touch any of its twins, nodes, farms,
+// contracts, accounts or other ids. A filter without predicates matches all
+// events. Events that carry none of these ids, like the council membership
+// events or BurnTransactionProcessed which only has the stellar target of
+// the burn, never match a filter with predicates
+type EventFilter struct {
+	Twins     []uint32
+	Nodes     []uint32
+	Farms     []uint32
+	Contracts []uint64
+	// ServiceContracts are ids of service contracts, which are not in the
+	// same ids space as other contracts
+	ServiceContracts  []uint64
+	SolutionProviders []uint64
+	Entities          []uint32
+	Accounts          []AccountID
+	// BurnTransactions are ids of the bridge burn transactions
+	BurnTransactions []uint64
+	// MintTransactions and RefundTransactions are hashes of the stellar
+	// transactions of bridge mints and refunds
+	MintTransactions   []string
+	RefundTransactions []string
+}
+
+// FilteredEvent is an event matched by a filter, with its block and origin
+type FilteredEvent struct {
+	Block uint32
+	Hash  types.Hash
+	// Extrinsic is the index of the extrinsic that emitted the event in
+	// the block, or -1 for events emitted outside of extrinsics
+	Extrinsic int
+	// Name of the event, like SmartContractModule_ContractCreated
+	Name     string
+	Event    interface{}
+	Reverted bool
+}
+
+// eventEntities are the ids and accounts an event refers to
+type eventEntities struct {
+	twins             []types.U32
+	nodes             []types.U32
+	farms             []types.U32
+	contracts         []types.U64
+	serviceContracts  []types.U64
+	solutionProviders []types.U64
+	entities          []types.U32
+	accounts          []AccountID
+	burns             []types.U64
+	mints             []string
+	refunds           []string
+}
+
+// Match checks if an event matches the filter
+func (f *EventFilter) Match(event interface{}) bool {
+	if f.empty() {
+		return true
+	}
+
+	e := entitiesOf(event)
+	for _, id := range e.twins {
+		if containsU32(f.Twins, uint32(id)) {
+			return true
+		}
+	}
+
+	for _, id := range e.nodes {
+		if containsU32(f.Nodes, uint32(id)) {
+			return true
+		}
+	}
+
+	for _, id := range e.farms {
+		if containsU32(f.Farms, uint32(id)) {
+			return true
+		}
+	}
+
+	for _, id := range e.contracts {
+		if containsU64(f.Contracts, uint64(id)) {
+			return true
+		}
+	}
+
+	for _, id := range e.serviceContracts {
+		if containsU64(f.ServiceContracts, uint64(id)) {
+			return true
+		}
+	}
+
+	for _, id := range e.solutionProviders {
+		if containsU64(f.SolutionProviders, uint64(id)) {
+			return true
+		}
+	}
+
+	for _, id := range e.entities {
+		if containsU32(f.Entities, uint32(id)) {
+			return true
+		}
+	}
+
+	for _, account := range e.accounts {
+		for _, other := range f.Accounts {
+			if account == other {
+				return true
+			}
+		}
+	}
+
+	for _, id := range e.burns {
+		if containsU64(f.BurnTransactions, uint64(id)) {
+			return true
+		}
+	}
+
+	for _, hash := range e.mints {
+		if containsString(f.MintTransactions, hash) {
+			return true
+		}
+	}
+
+	for _, hash := range e.refunds {
+		if containsString(f.RefundTransactions, hash) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Events returns the events of records matching the filter, in the order
+// they were emitted
+func (f *EventFilter) Events(records *EventRecords) []FilteredEvent {
+	return f.Filter(BlockEvents{Events: records})
+}
+
+// Filter returns the events of a block matching the filter, in the order
+// they were emitted
+func (f *EventFilter) Filter(block BlockEvents) []FilteredEvent {
+	if block.Events == nil {
+		return nil
+	}
+
+	var matched []FilteredEvent
+	for _, ref := range block.Events.Order {
+		event := block.Events.Event(ref)
+		if event == nil || !f.Match(event) {
+			continue
+		}
+
+		extrinsic := -1
+		if ref.Phase.IsApplyExtrinsic {
+			extrinsic = int(ref.Phase.AsApplyExtrinsic)
+		}
+
+		matched = append(matched, FilteredEvent{
+			Block:     block.Number,
+			Hash:      block.Hash,
+			Extrinsic: extrinsic,
+			Name:      ref.Name,
+			Event:     event,
+			Reverted:  block.Reverted,
+		})
+	}
+
+	return matched
+}
+
+// Run calls fn with the matching events of all blocks received from a
+// subscription until the channel is closed, it returns the error of the
+// subscription if any
+func (f *EventFilter) Run(blocks <-chan BlockEvents, fn func(FilteredEvent)) error {
+	for block := range blocks {
+		if block.Err != nil {
+			return block.Err
+		}
+
+		for _, event := range f.Filter(block) {
+			fn(event)
+		}
+	}
+
+	return nil
+}
+
+func (f *EventFilter) empty() bool {
+	return len(f.Twins) == 0 && len(f.Nodes) == 0 && len(f.Farms) == 0 &&
+		len(f.Contracts) == 0 && len(f.ServiceContracts) == 0 && len(f.SolutionProviders) == 0 &&
+		len(f.Entities) == 0 && len(f.Accounts) == 0 && len(f.BurnTransactions) == 0 &&
+		len(f.MintTransactions) == 0 && len(f.RefundTransactions) == 0
+}
+
+func containsU32(ids []uint32, id uint32) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsU64(ids []uint64, id uint64) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// entitiesOf returns the ids and accounts referenced by a decoded event,
+// events without any (MemberEvent, BurnTransactionProcessed, ...) are not
+// listed
+func entitiesOf(event interface{}) (e eventEntities) {
+	switch ev := event.(type) {
+	// contracts
+	case ContractCreated:
+		e.contract(ev.Contract)
+	case ContractUpdated:
+		e.contract(ev.Contract)
+	case NodeContractCanceled:
+		e.contracts = append(e.contracts, ev.ContractID)
+		e.nodes = append(e.nodes, ev.Node)
+		e.twins = append(e.twins, ev.Twin)
+	case NameContractCanceled:
+		e.contracts = append(e.contracts, ev.ContractID)
+	case RentContractCanceled:
+		e.contracts = append(e.contracts, ev.ContractID)
+	case IPsReserved:
+		e.contracts = append(e.contracts, ev.ContractID)
+	case IPsFreed:
+		e.contracts = append(e.contracts, ev.ContractID)
+	case TokensBurned:
+		e.contracts = append(e.contracts, ev.ContractID)
+	case ContractDeployed:
+		e.contracts = append(e.contracts, ev.ContractID)
+		e.accounts = append(e.accounts, ev.AccountID)
+	case ConsumptionReportReceived:
+		e.contracts = append(e.contracts, ev.Consumption.ContractID)
+	case NruConsumptionReportReceived:
+		e.contracts = append(e.contracts, ev.Consumption.ContractID)
+	case ContractBilled:
+		e.contracts = append(e.contracts, ev.ContractBill.ContractID)
+	case UpdatedUsedResources:
+		e.contracts = append(e.contracts, ev.ContractResources.ContractID)
+	case ContractGracePeriodStarted:
+		e.contracts = append(e.contracts, ev.ContractID)
+		e.nodes = append(e.nodes, ev.NodeID)
+		e.twins = append(e.twins, ev.TwinID)
+	case ContractGracePeriodEnded:
+		e.contracts = append(e.contracts, ev.ContractID)
+		e.nodes = append(e.nodes, ev.NodeID)
+		e.twins = append(e.twins, ev.TwinID)
+	case NodeMarkAsDedicated:
+		e.nodes = append(e.nodes, ev.NodeID)
+	case SolutionProviderCreated:
+		e.solutionProviders = append(e.solutionProviders, ev.SolutionProvider.SolutionProviderID)
+		for _, provider := range ev.SolutionProvider.Providers {
+			e.accounts = append(e.accounts, AccountID(provider.Who))
+		}
+	case SolutionProviderApproved:
+		e.solutionProviders = append(e.solutionProviders, ev.SolutionProviderID)
+	case ServiceContractCreated:
+		e.serviceContract(ev.ServiceContract)
+	case ServiceContractCanceled:
+		e.serviceContracts = append(e.serviceContracts, ev.ServiceContractID)
+	case ServiceContractBilled:
+		e.serviceContract(ev.ServiceContract)
+
+	// farms
+	case FarmStored:
+		e.farms = append(e.farms, ev.Farm.ID)
+		e.twins = append(e.twins, ev.Farm.TwinID)
+	case FarmDeleted:
+		e.farms = append(e.farms, ev.Farm)
+	case FarmPayoutV2AddressRegistered:
+		e.farms = append(e.farms, ev.Farm)
+	case FarmMarkedAsDedicated:
+		e.farms = append(e.farms, ev.Farm)
+	case FarmingPolicySet:
+		e.farms = append(e.farms, ev.Farm)
+	case FarmCertificationSet:
+		e.farms = append(e.farms, ev.Farm)
+
+	// nodes
+	case NodeStored:
+		e.nodes = append(e.nodes, ev.Node.ID)
+		e.farms = append(e.farms, ev.Node.FarmID)
+		e.twins = append(e.twins, ev.Node.TwinID)
+	case NodeDeleted:
+		e.nodes = append(e.nodes, ev.Node)
+	case NodeUptimeReported:
+		e.nodes = append(e.nodes, ev.Node)
+	case NodePublicConfig:
+		e.nodes = append(e.nodes, ev.Node)
+	case NodeCertificationSet:
+		e.nodes = append(e.nodes, ev.NodeId)
+	case PowerTargetChanged:
+		e.nodes = append(e.nodes, ev.Node)
+		e.farms = append(e.farms, ev.Farm)
+	case PowerStateChanged:
+		e.nodes = append(e.nodes, ev.Node)
+		e.farms = append(e.farms, ev.Farm)
+
+	// twins and entities
+	case TwinStored:
+		e.twins = append(e.twins, ev.Twin.ID)
+		e.accounts = append(e.accounts, ev.Twin.Account)
+	case TwinDeleted:
+		e.twins = append(e.twins, ev.Twin)
+	case TwinEntityStored:
+		e.twins = append(e.twins, ev.Twin)
+		e.entities = append(e.entities, ev.Entity)
+	case TwinEntityRemoved:
+		e.twins = append(e.twins, ev.Twin)
+		e.entities = append(e.entities, ev.Entity)
+	case EntityStored:
+		e.entities = append(e.entities, ev.Entity.ID)
+		e.accounts = append(e.accounts, ev.Entity.Account)
+	case EntityDeleted:
+		e.entities = append(e.entities, ev.Entity)
+
+	// accounts
+	case NodeCertifierAdded:
+		e.accounts = append(e.accounts, ev.Address)
+	case NodeCertifierRemoved:
+		e.accounts = append(e.accounts, ev.Address)
+	case OffchainWorkerExecuted:
+		e.accounts = append(e.accounts, ev.Account)
+	case EntryEvent:
+		e.accounts = append(e.accounts, ev.Account)
+	case ValidatorAdded:
+		e.accounts = append(e.accounts, ev.Account)
+	case ValidatorRemoved:
+		e.accounts = append(e.accounts, ev.Account)
+	case Bonded:
+		e.accounts = append(e.accounts, ev.Account)
+	case ValidatorCreated:
+		e.accounts = append(e.accounts, ev.Account, ev.Validator.ValidatorNodeAccount, ev.Validator.StashAccount)
+	case ValidatorApproved:
+		e.accounts = append(e.accounts, ev.Validator.ValidatorNodeAccount, ev.Validator.StashAccount)
+	case Voted:
+		e.accounts = append(e.accounts, ev.Account)
+	case Proposed:
+		e.accounts = append(e.accounts, ev.Account)
+	case ClosedByCouncil:
+		e.accounts = append(e.accounts, ev.Vetos...)
+	case CouncilMemberVeto:
+		e.accounts = append(e.accounts, ev.Who)
+	case BurnTransactionCreated:
+		e.accounts = append(e.accounts, ev.Target)
+
+	// bridge
+	case BridgeBurnTransactionCreated:
+		e.burns = append(e.burns, ev.BurnTransactionID)
+		e.accounts = append(e.accounts, AccountID(ev.Source))
+	case BridgeBurnTransactionExpired:
+		e.burns = append(e.burns, ev.BurnTransactionID)
+	case BurnTransactionReady:
+		e.burns = append(e.burns, ev.BurnTransactionID)
+	case BurnTransactionSignatureAdded:
+		e.burns = append(e.burns, ev.BurnTransactionID)
+	case BurnTransactionProposed:
+		e.burns = append(e.burns, ev.BurnTransactionID)
+	case RefundTransactionCreated:
+		e.refunds = append(e.refunds, string(ev.RefundTransactionHash))
+	case RefundTransactionSignatureAdded:
+		e.refunds = append(e.refunds, string(ev.RefundTransactionHash))
+	case RefundTransactionReady:
+		e.refunds = append(e.refunds, string(ev.RefundTransactionHash))
+	case RefundTransactionProcessed:
+		e.refunds = append(e.refunds, ev.RefundTransactionHash.TxHash)
+	case MintTransactionProposed:
+		e.mints = append(e.mints, ev.TxHash)
+		e.accounts = append(e.accounts, ev.Target)
+	case MintTransactionVoted:
+		e.mints = append(e.mints, ev.TxHash)
+	case MintTransactionExpired:
+		e.mints = append(e.mints, ev.TxHash)
+		e.accounts = append(e.accounts, ev.Target)
+	case MintCompleted:
+		e.accounts = append(e.accounts, AccountID(ev.MintTransaction.Target))
+
+	// balances and system
+	case types.EventBalancesEndowed:
+		e.accounts = append(e.accounts, AccountID(ev.Who))
+	case types.EventBalancesDustLost:
+		e.accounts = append(e.accounts, AccountID(ev.Who))
+	case types.EventBalancesTransfer:
+		e.accounts = append(e.accounts, AccountID(ev.From), AccountID(ev.To))
+	case types.EventBalancesBalanceSet:
+		e.accounts = append(e.accounts, AccountID(ev.Who))
+	case types.EventBalancesDeposit:
+		e.accounts = append(e.accounts, AccountID(ev.Who))
+	case types.EventBalancesReserved:
+		e.accounts = append(e.accounts, AccountID(ev.Who))
+	case types.EventBalancesUnreserved:
+		e.accounts = append(e.accounts, AccountID(ev.Who))
+	case types.EventBalancesReserveRepatriated:
+		e.accounts = append(e.accounts, AccountID(ev.From), AccountID(ev.To))
+	case types.EventBalancesWithdraw:
+		e.accounts = append(e.accounts, AccountID(ev.Who))
+	case types.EventBalancesSlashed:
+		e.accounts = append(e.accounts, AccountID(ev.Who))
+	case types.EventSystemNewAccount:
+		e.accounts = append(e.accounts, AccountID(ev.Who))
+	case types.EventSystemKilledAccount:
+		e.accounts = append(e.accounts, AccountID(ev.Who))
+	case types.EventSystemRemarked:
+		e.accounts = append(e.accounts, AccountID(ev.Who))
+	}
+
+	return e
+}
+
+func (e *eventEntities) contract(contract Contract) {
+	e.contracts = append(e.contracts, contract.ContractID)
+	e.twins = append(e.twins, contract.TwinID)
+	if ok, id := contract.SolutionProviderID.Unwrap(); ok {
+		e.solutionProviders = append(e.solutionProviders, id)
+	}
+
+	switch {
+	case contract.ContractType.IsNodeContract:
+		e.nodes = append(e.nodes, contract.ContractType.NodeContract.Node)
+	case contract.ContractType.IsRentContract:
+		e.nodes = append(e.nodes, contract.ContractType.RentContract.Node)
+	}
+}
+
+func (e *eventEntities) serviceContract(contract ServiceContract) {
+	e.serviceContracts = append(e.serviceContracts, contract.ServiceContractID)
+	e.twins = append(e.twins, contract.ServiceTwinID, contract.ConsumerTwinID)
+}
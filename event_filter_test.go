@@ -0,0 +1,112 @@
+package substrate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventFilter(t *testing.T) {
+	extrinsic := types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: 2}
+	account := AccountID{1}
+
+	events := EventRecords{
+		SmartContractModule_ContractCreated: []ContractCreated{{
+			Phase: extrinsic,
+			Contract: Contract{
+				ContractID:   10,
+				TwinID:       1,
+				ContractType: ContractType{IsNodeContract: true, NodeContract: NodeContract{Node: 5}},
+			},
+		}},
+		TfgridModule_NodeStored:         []NodeStored{{Phase: extrinsic, Node: Node{ID: 6, FarmID: 3, TwinID: 2}}},
+		TfgridModule_NodeUptimeReported: []NodeUptimeReported{{Phase: types.Phase{IsFinalization: true}, Node: 5}},
+		SmartContractModule_ServiceContractCreated: []ServiceContractCreated{{
+			Phase:           extrinsic,
+			ServiceContract: ServiceContract{ServiceContractID: 10, ServiceTwinID: 7, ConsumerTwinID: 8},
+		}},
+	}
+	events.Balances_Transfer = []types.EventBalancesTransfer{{Phase: extrinsic, From: types.AccountID(account), To: types.AccountID{2}}}
+	events.Order = []EventRef{
+		{Phase: extrinsic, Name: "SmartContractModule_ContractCreated"},
+		{Phase: extrinsic, Name: "TfgridModule_NodeStored"},
+		{Phase: types.Phase{IsFinalization: true}, Name: "TfgridModule_NodeUptimeReported"},
+		{Phase: extrinsic, Name: "SmartContractModule_ServiceContractCreated"},
+		{Phase: extrinsic, Name: "Balances_Transfer"},
+	}
+
+	names := func(filtered []FilteredEvent) []string {
+		var names []string
+		for _, event := range filtered {
+			names = append(names, event.Name)
+		}
+		return names
+	}
+
+	filter := EventFilter{Nodes: []uint32{5}}
+	matched := filter.Filter(BlockEvents{Number: 42, Events: &events, Reverted: true})
+	require.Equal(t, []string{"SmartContractModule_ContractCreated", "TfgridModule_NodeUptimeReported"}, names(matched))
+	require.Equal(t, uint32(42), matched[0].Block)
+	require.Equal(t, 2, matched[0].Extrinsic)
+	require.Equal(t, -1, matched[1].Extrinsic)
+	require.True(t, matched[0].Reverted)
+	require.Equal(t, events.SmartContractModule_ContractCreated[0], matched[0].Event)
+
+	filter = EventFilter{Farms: []uint32{3}, Accounts: []AccountID{account}}
+	require.Equal(t, []string{"TfgridModule_NodeStored", "Balances_Transfer"}, names(filter.Events(&events)))
+
+	// contract and service contract ids don't mix
+	filter = EventFilter{Contracts: []uint64{10}}
+	require.Equal(t, []string{"SmartContractModule_ContractCreated"}, names(filter.Events(&events)))
+	filter = EventFilter{ServiceContracts: []uint64{10}}
+	require.Equal(t, []string{"SmartContractModule_ServiceContractCreated"}, names(filter.Events(&events)))
+
+	filter = EventFilter{Twins: []uint32{99}}
+	require.Empty(t, filter.Events(&events))
+
+	filter = EventFilter{}
+	require.Len(t, filter.Events(&events), 5)
+
+	blocks := make(chan BlockEvents, 2)
+	blocks <- BlockEvents{Number: 1, Events: &events}
+	blocks <- BlockEvents{Err: errors.New("connection lost")}
+	close(blocks)
+
+	var received []FilteredEvent
+	filter = EventFilter{Twins: []uint32{8}}
+	err := filter.Run(blocks, func(event FilteredEvent) {
+		received = append(received, event)
+	})
+	require.EqualError(t, err, "connection lost")
+	require.Equal(t, []string{"SmartContractModule_ServiceContractCreated"}, names(received))
+}
+
+func TestEventFilterIDs(t *testing.T) {
+	cases := []struct {
+		name   string
+		filter EventFilter
+		event  interface{}
+	}{
+		{"solution provider", EventFilter{SolutionProviders: []uint64{3}}, SolutionProviderApproved{SolutionProviderID: 3, Approved: true}},
+		{"contract solution provider", EventFilter{SolutionProviders: []uint64{3}}, ContractCreated{Contract: Contract{SolutionProviderID: types.NewOptionU64(3)}}},
+		{"entity", EventFilter{Entities: []uint32{4}}, EntityDeleted{Entity: 4}},
+		{"twin entity", EventFilter{Entities: []uint32{4}}, TwinEntityRemoved{Twin: 1, Entity: 4}},
+		{"burn", EventFilter{BurnTransactions: []uint64{5}}, BurnTransactionReady{BurnTransactionID: 5}},
+		{"refund", EventFilter{RefundTransactions: []string{"hash"}}, RefundTransactionReady{RefundTransactionHash: []byte("hash")}},
+		{"refund processed", EventFilter{RefundTransactions: []string{"hash"}}, RefundTransactionProcessed{RefundTransactionHash: RefundTransaction{TxHash: "hash"}}},
+		{"mint", EventFilter{MintTransactions: []string{"hash"}}, MintTransactionVoted{TxHash: "hash"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			require.True(t, c.filter.Match(c.event))
+			require.False(t, (&EventFilter{Twins: []uint32{1 << 20}}).Match(c.event))
+		})
+	}
+
+	// events without ids only match an empty filter
+	require.False(t, (&EventFilter{Accounts: []AccountID{{1}}}).Match(MemberEvent{}))
+	require.True(t, (&EventFilter{}).Match(MemberEvent{}))
+}
@@ -0,0 +1,589 @@
+package substrate
+
+// EventHandlers dispatches the events of a block to registered handlers.
+// Handlers are called in the order the events were emitted in the block
+type EventHandlers struct {
+	blocks   []func(BlockEvents)
+	reverted []func(BlockEvents)
+	events   map[string][]func(interface{})
+}
+
+// OnBlock registers a handler called for every block, before the
+// handlers of its events
+func (h *EventHandlers) OnBlock(fn func(BlockEvents)) {
+	h.blocks = append(h.blocks, fn)
+}
+
+// OnRevert registers a handler called for blocks reverted by a chain
+// reorganization, event handlers are not called for reverted blocks
+func (h *EventHandlers) OnRevert(fn func(BlockEvents)) {
+	h.reverted = append(h.reverted, fn)
+}
+
+// On registers a handler for events with the given EventRecords field
+// name, like SmartContractModule_ContractCreated
+func (h *EventHandlers) On(name string, fn func(event interface{})) {
+	if h.events == nil {
+		h.events = make(map[string][]func(interface{}))
+	}
+
+	h.events[name] = append(h.events[name], fn)
+}
+
+// Handle calls the registered handlers with the events of a block
+func (h *EventHandlers) Handle(block BlockEvents) {
+	if block.Reverted {
+		for _, fn := range h.reverted {
+			fn(block)
+		}
+		return
+	}
+
+	for _, fn := range h.blocks {
+		fn(block)
+	}
+
+	if block.Events == nil {
+		return
+	}
+
+	for _, ref := range block.Events.Order {
+		handlers := h.events[ref.Name]
+		if len(handlers) == 0 {
+			continue
+		}
+
+		event := block.Events.Event(ref)
+		if event == nil {
+			continue
+		}
+
+		for _, fn := range handlers {
+			fn(event)
+		}
+	}
+}
+
+// Run handles all blocks received from a subscription until the channel is
+// closed, it returns the error of the subscription if any
+func (h *EventHandlers) Run(blocks <-chan BlockEvents) error {
+	for block := range blocks {
+		if block.Err != nil {
+			return block.Err
+		}
+
+		h.Handle(block)
+	}
+
+	return nil
+}
+
+// OnContractCreated registers a handler for SmartContractModule.ContractCreated events
+func (h *EventHandlers) OnContractCreated(fn func(ContractCreated)) {
+	h.On("SmartContractModule_ContractCreated", func(event interface{}) { fn(event.(ContractCreated)) })
+}
+
+// OnContractUpdated registers a handler for SmartContractModule.ContractUpdated events
+func (h *EventHandlers) OnContractUpdated(fn func(ContractUpdated)) {
+	h.On("SmartContractModule_ContractUpdated", func(event interface{}) { fn(event.(ContractUpdated)) })
+}
+
+// OnNodeContractCanceled registers a handler for SmartContractModule.NodeContractCanceled events
+func (h *EventHandlers) OnNodeContractCanceled(fn func(NodeContractCanceled)) {
+	h.On("SmartContractModule_NodeContractCanceled", func(event interface{}) { fn(event.(NodeContractCanceled)) })
+}
+
+// OnNameContractCanceled registers a handler for SmartContractModule.NameContractCanceled events
+func (h *EventHandlers) OnNameContractCanceled(fn func(NameContractCanceled)) {
+	h.On("SmartContractModule_NameContractCanceled", func(event interface{}) { fn(event.(NameContractCanceled)) })
+}
+
+// OnIPsReserved registers a handler for SmartContractModule.IPsReserved events
+func (h *EventHandlers) OnIPsReserved(fn func(IPsReserved)) {
+	h.On("SmartContractModule_IPsReserved", func(event interface{}) { fn(event.(IPsReserved)) })
+}
+
+// OnIPsFreed registers a handler for SmartContractModule.IPsFreed events
+func (h *EventHandlers) OnIPsFreed(fn func(IPsFreed)) {
+	h.On("SmartContractModule_IPsFreed", func(event interface{}) { fn(event.(IPsFreed)) })
+}
+
+// OnContractDeployed registers a handler for SmartContractModule.ContractDeployed events
+func (h *EventHandlers) OnContractDeployed(fn func(ContractDeployed)) {
+	h.On("SmartContractModule_ContractDeployed", func(event interface{}) { fn(event.(ContractDeployed)) })
+}
+
+// OnConsumptionReportReceived registers a handler for SmartContractModule.ConsumptionReportReceived events
+func (h *EventHandlers) OnConsumptionReportReceived(fn func(ConsumptionReportReceived)) {
+	h.On("SmartContractModule_ConsumptionReportReceived", func(event interface{}) { fn(event.(ConsumptionReportReceived)) })
+}
+
+// OnContractBilled registers a handler for SmartContractModule.ContractBilled events
+func (h *EventHandlers) OnContractBilled(fn func(ContractBilled)) {
+	h.On("SmartContractModule_ContractBilled", func(event interface{}) { fn(event.(ContractBilled)) })
+}
+
+// OnTokensBurned registers a handler for SmartContractModule.TokensBurned events
+func (h *EventHandlers) OnTokensBurned(fn func(TokensBurned)) {
+	h.On("SmartContractModule_TokensBurned", func(event interface{}) { fn(event.(TokensBurned)) })
+}
+
+// OnUpdatedUsedResources registers a handler for SmartContractModule.UpdatedUsedResources events
+func (h *EventHandlers) OnUpdatedUsedResources(fn func(UpdatedUsedResources)) {
+	h.On("SmartContractModule_UpdatedUsedResources", func(event interface{}) { fn(event.(UpdatedUsedResources)) })
+}
+
+// OnNruConsumptionReportReceived registers a handler for SmartContractModule.NruConsumptionReportReceived events
+func (h *EventHandlers) OnNruConsumptionReportReceived(fn func(NruConsumptionReportReceived)) {
+	h.On("SmartContractModule_NruConsumptionReportReceived", func(event interface{}) { fn(event.(NruConsumptionReportReceived)) })
+}
+
+// OnRentContractCanceled registers a handler for SmartContractModule.RentContractCanceled events
+func (h *EventHandlers) OnRentContractCanceled(fn func(RentContractCanceled)) {
+	h.On("SmartContractModule_RentContractCanceled", func(event interface{}) { fn(event.(RentContractCanceled)) })
+}
+
+// OnContractGracePeriodStarted registers a handler for SmartContractModule.ContractGracePeriodStarted events
+func (h *EventHandlers) OnContractGracePeriodStarted(fn func(ContractGracePeriodStarted)) {
+	h.On("SmartContractModule_ContractGracePeriodStarted", func(event interface{}) { fn(event.(ContractGracePeriodStarted)) })
+}
+
+// OnContractGracePeriodEnded registers a handler for SmartContractModule.ContractGracePeriodEnded events
+func (h *EventHandlers) OnContractGracePeriodEnded(fn func(ContractGracePeriodEnded)) {
+	h.On("SmartContractModule_ContractGracePeriodEnded", func(event interface{}) { fn(event.(ContractGracePeriodEnded)) })
+}
+
+// OnNodeMarkedAsDedicated registers a handler for SmartContractModule.NodeMarkedAsDedicated events
+func (h *EventHandlers) OnNodeMarkedAsDedicated(fn func(NodeMarkAsDedicated)) {
+	h.On("SmartContractModule_NodeMarkedAsDedicated", func(event interface{}) { fn(event.(NodeMarkAsDedicated)) })
+}
+
+// OnSolutionProviderCreated registers a handler for SmartContractModule.SolutionProviderCreated events
+func (h *EventHandlers) OnSolutionProviderCreated(fn func(SolutionProviderCreated)) {
+	h.On("SmartContractModule_SolutionProviderCreated", func(event interface{}) { fn(event.(SolutionProviderCreated)) })
+}
+
+// OnSolutionProviderApproved registers a handler for SmartContractModule.SolutionProviderApproved events
+func (h *EventHandlers) OnSolutionProviderApproved(fn func(SolutionProviderApproved)) {
+	h.On("SmartContractModule_SolutionProviderApproved", func(event interface{}) { fn(event.(SolutionProviderApproved)) })
+}
+
+// OnServiceContractCreated registers a handler for SmartContractModule.ServiceContractCreated events
+func (h *EventHandlers) OnServiceContractCreated(fn func(ServiceContractCreated)) {
+	h.On("SmartContractModule_ServiceContractCreated", func(event interface{}) { fn(event.(ServiceContractCreated)) })
+}
+
+// OnServiceContractMetadataSet registers a handler for SmartContractModule.ServiceContractMetadataSet events
+func (h *EventHandlers) OnServiceContractMetadataSet(fn func(ServiceContractCreated)) {
+	h.On("SmartContractModule_ServiceContractMetadataSet", func(event interface{}) { fn(event.(ServiceContractCreated)) })
+}
+
+// OnServiceContractFeesSet registers a handler for SmartContractModule.ServiceContractFeesSet events
+func (h *EventHandlers) OnServiceContractFeesSet(fn func(ServiceContractCreated)) {
+	h.On("SmartContractModule_ServiceContractFeesSet", func(event interface{}) { fn(event.(ServiceContractCreated)) })
+}
+
+// OnServiceContractApproved registers a handler for SmartContractModule.ServiceContractApproved events
+func (h *EventHandlers) OnServiceContractApproved(fn func(ServiceContractCreated)) {
+	h.On("SmartContractModule_ServiceContractApproved", func(event interface{}) { fn(event.(ServiceContractCreated)) })
+}
+
+// OnServiceContractCanceled registers a handler for SmartContractModule.ServiceContractCanceled events
+func (h *EventHandlers) OnServiceContractCanceled(fn func(ServiceContractCanceled)) {
+	h.On("SmartContractModule_ServiceContractCanceled", func(event interface{}) { fn(event.(ServiceContractCanceled)) })
+}
+
+// OnServiceContractBilled registers a handler for SmartContractModule.ServiceContractBilled events
+func (h *EventHandlers) OnServiceContractBilled(fn func(ServiceContractBilled)) {
+	h.On("SmartContractModule_ServiceContractBilled", func(event interface{}) { fn(event.(ServiceContractBilled)) })
+}
+
+// OnBillingFrequencyChanged registers a handler for SmartContractModule.BillingFrequencyChanged events
+func (h *EventHandlers) OnBillingFrequencyChanged(fn func(BillingFrequencyChanged)) {
+	h.On("SmartContractModule_BillingFrequencyChanged", func(event interface{}) { fn(event.(BillingFrequencyChanged)) })
+}
+
+// OnFarmStored registers a handler for TfgridModule.FarmStored events
+func (h *EventHandlers) OnFarmStored(fn func(FarmStored)) {
+	h.On("TfgridModule_FarmStored", func(event interface{}) { fn(event.(FarmStored)) })
+}
+
+// OnFarmUpdated registers a handler for TfgridModule.FarmUpdated events
+func (h *EventHandlers) OnFarmUpdated(fn func(FarmStored)) {
+	h.On("TfgridModule_FarmUpdated", func(event interface{}) { fn(event.(FarmStored)) })
+}
+
+// OnFarmDeleted registers a handler for TfgridModule.FarmDeleted events
+func (h *EventHandlers) OnFarmDeleted(fn func(FarmDeleted)) {
+	h.On("TfgridModule_FarmDeleted", func(event interface{}) { fn(event.(FarmDeleted)) })
+}
+
+// OnNodeStored registers a handler for TfgridModule.NodeStored events
+func (h *EventHandlers) OnNodeStored(fn func(NodeStored)) {
+	h.On("TfgridModule_NodeStored", func(event interface{}) { fn(event.(NodeStored)) })
+}
+
+// OnNodeUpdated registers a handler for TfgridModule.NodeUpdated events
+func (h *EventHandlers) OnNodeUpdated(fn func(NodeStored)) {
+	h.On("TfgridModule_NodeUpdated", func(event interface{}) { fn(event.(NodeStored)) })
+}
+
+// OnNodeDeleted registers a handler for TfgridModule.NodeDeleted events
+func (h *EventHandlers) OnNodeDeleted(fn func(NodeDeleted)) {
+	h.On("TfgridModule_NodeDeleted", func(event interface{}) { fn(event.(NodeDeleted)) })
+}
+
+// OnNodeUptimeReported registers a handler for TfgridModule.NodeUptimeReported events
+func (h *EventHandlers) OnNodeUptimeReported(fn func(NodeUptimeReported)) {
+	h.On("TfgridModule_NodeUptimeReported", func(event interface{}) { fn(event.(NodeUptimeReported)) })
+}
+
+// OnNodePublicConfigStored registers a handler for TfgridModule.NodePublicConfigStored events
+func (h *EventHandlers) OnNodePublicConfigStored(fn func(NodePublicConfig)) {
+	h.On("TfgridModule_NodePublicConfigStored", func(event interface{}) { fn(event.(NodePublicConfig)) })
+}
+
+// OnPowerTargetChanged registers a handler for TfgridModule.PowerTargetChanged events
+func (h *EventHandlers) OnPowerTargetChanged(fn func(PowerTargetChanged)) {
+	h.On("TfgridModule_PowerTargetChanged", func(event interface{}) { fn(event.(PowerTargetChanged)) })
+}
+
+// OnPowerStateChanged registers a handler for TfgridModule.PowerStateChanged events
+func (h *EventHandlers) OnPowerStateChanged(fn func(PowerStateChanged)) {
+	h.On("TfgridModule_PowerStateChanged", func(event interface{}) { fn(event.(PowerStateChanged)) })
+}
+
+// OnEntityStored registers a handler for TfgridModule.EntityStored events
+func (h *EventHandlers) OnEntityStored(fn func(EntityStored)) {
+	h.On("TfgridModule_EntityStored", func(event interface{}) { fn(event.(EntityStored)) })
+}
+
+// OnEntityUpdated registers a handler for TfgridModule.EntityUpdated events
+func (h *EventHandlers) OnEntityUpdated(fn func(EntityStored)) {
+	h.On("TfgridModule_EntityUpdated", func(event interface{}) { fn(event.(EntityStored)) })
+}
+
+// OnEntityDeleted registers a handler for TfgridModule.EntityDeleted events
+func (h *EventHandlers) OnEntityDeleted(fn func(EntityDeleted)) {
+	h.On("TfgridModule_EntityDeleted", func(event interface{}) { fn(event.(EntityDeleted)) })
+}
+
+// OnTwinStored registers a handler for TfgridModule.TwinStored events
+func (h *EventHandlers) OnTwinStored(fn func(TwinStored)) {
+	h.On("TfgridModule_TwinStored", func(event interface{}) { fn(event.(TwinStored)) })
+}
+
+// OnTwinUpdated registers a handler for TfgridModule.TwinUpdated events
+func (h *EventHandlers) OnTwinUpdated(fn func(TwinStored)) {
+	h.On("TfgridModule_TwinUpdated", func(event interface{}) { fn(event.(TwinStored)) })
+}
+
+// OnTwinDeleted registers a handler for TfgridModule.TwinDeleted events
+func (h *EventHandlers) OnTwinDeleted(fn func(TwinDeleted)) {
+	h.On("TfgridModule_TwinDeleted", func(event interface{}) { fn(event.(TwinDeleted)) })
+}
+
+// OnTwinEntityStored registers a handler for TfgridModule.TwinEntityStored events
+func (h *EventHandlers) OnTwinEntityStored(fn func(TwinEntityStored)) {
+	h.On("TfgridModule_TwinEntityStored", func(event interface{}) { fn(event.(TwinEntityStored)) })
+}
+
+// OnTwinEntityRemoved registers a handler for TfgridModule.TwinEntityRemoved events
+func (h *EventHandlers) OnTwinEntityRemoved(fn func(TwinEntityRemoved)) {
+	h.On("TfgridModule_TwinEntityRemoved", func(event interface{}) { fn(event.(TwinEntityRemoved)) })
+}
+
+// OnPricingPolicyStored registers a handler for TfgridModule.PricingPolicyStored events
+func (h *EventHandlers) OnPricingPolicyStored(fn func(PricingPolicyStored)) {
+	h.On("TfgridModule_PricingPolicyStored", func(event interface{}) { fn(event.(PricingPolicyStored)) })
+}
+
+// OnFarmingPolicyStored registers a handler for TfgridModule.FarmingPolicyStored events
+func (h *EventHandlers) OnFarmingPolicyStored(fn func(FarmingPolicyStored)) {
+	h.On("TfgridModule_FarmingPolicyStored", func(event interface{}) { fn(event.(FarmingPolicyStored)) })
+}
+
+// OnFarmPayoutV2AddressRegistered registers a handler for TfgridModule.FarmPayoutV2AddressRegistered events
+func (h *EventHandlers) OnFarmPayoutV2AddressRegistered(fn func(FarmPayoutV2AddressRegistered)) {
+	h.On("TfgridModule_FarmPayoutV2AddressRegistered", func(event interface{}) { fn(event.(FarmPayoutV2AddressRegistered)) })
+}
+
+// OnFarmMarkedAsDedicated registers a handler for TfgridModule.FarmMarkedAsDedicated events
+func (h *EventHandlers) OnFarmMarkedAsDedicated(fn func(FarmMarkedAsDedicated)) {
+	h.On("TfgridModule_FarmMarkedAsDedicated", func(event interface{}) { fn(event.(FarmMarkedAsDedicated)) })
+}
+
+// OnConnectionPriceSet registers a handler for TfgridModule.ConnectionPriceSet events
+func (h *EventHandlers) OnConnectionPriceSet(fn func(ConnectionPriceSet)) {
+	h.On("TfgridModule_ConnectionPriceSet", func(event interface{}) { fn(event.(ConnectionPriceSet)) })
+}
+
+// OnNodeCertificationSet registers a handler for TfgridModule.NodeCertificationSet events
+func (h *EventHandlers) OnNodeCertificationSet(fn func(NodeCertificationSet)) {
+	h.On("TfgridModule_NodeCertificationSet", func(event interface{}) { fn(event.(NodeCertificationSet)) })
+}
+
+// OnNodeCertifierAdded registers a handler for TfgridModule.NodeCertifierAdded events
+func (h *EventHandlers) OnNodeCertifierAdded(fn func(NodeCertifierAdded)) {
+	h.On("TfgridModule_NodeCertifierAdded", func(event interface{}) { fn(event.(NodeCertifierAdded)) })
+}
+
+// OnNodeCertifierRemoved registers a handler for TfgridModule.NodeCertifierRemoved events
+func (h *EventHandlers) OnNodeCertifierRemoved(fn func(NodeCertifierRemoved)) {
+	h.On("TfgridModule_NodeCertifierRemoved", func(event interface{}) { fn(event.(NodeCertifierRemoved)) })
+}
+
+// OnFarmingPolicyUpdated registers a handler for TfgridModule.FarmingPolicyUpdated events
+func (h *EventHandlers) OnFarmingPolicyUpdated(fn func(FarmingPolicyUpdated)) {
+	h.On("TfgridModule_FarmingPolicyUpdated", func(event interface{}) { fn(event.(FarmingPolicyUpdated)) })
+}
+
+// OnFarmingPolicySet registers a handler for TfgridModule.FarmingPolicySet events
+func (h *EventHandlers) OnFarmingPolicySet(fn func(FarmingPolicySet)) {
+	h.On("TfgridModule_FarmingPolicySet", func(event interface{}) { fn(event.(FarmingPolicySet)) })
+}
+
+// OnFarmCertificationSet registers a handler for TfgridModule.FarmCertificationSet events
+func (h *EventHandlers) OnFarmCertificationSet(fn func(FarmCertificationSet)) {
+	h.On("TfgridModule_FarmCertificationSet", func(event interface{}) { fn(event.(FarmCertificationSet)) })
+}
+
+// OnZosVersionUpdated registers a handler for TfgridModule.ZosVersionUpdated events
+func (h *EventHandlers) OnZosVersionUpdated(fn func(ZosVersionUpdated)) {
+	h.On("TfgridModule_ZosVersionUpdated", func(event interface{}) { fn(event.(ZosVersionUpdated)) })
+}
+
+// OnBurnTransactionCreated registers a handler for BurningModule.BurnTransactionCreated events
+func (h *EventHandlers) OnBurnTransactionCreated(fn func(BurnTransactionCreated)) {
+	h.On("BurningModule_BurnTransactionCreated", func(event interface{}) { fn(event.(BurnTransactionCreated)) })
+}
+
+// OnMintTransactionProposed registers a handler for TFTBridgeModule.MintTransactionProposed events
+func (h *EventHandlers) OnMintTransactionProposed(fn func(MintTransactionProposed)) {
+	h.On("TFTBridgeModule_MintTransactionProposed", func(event interface{}) { fn(event.(MintTransactionProposed)) })
+}
+
+// OnMintTransactionVoted registers a handler for TFTBridgeModule.MintTransactionVoted events
+func (h *EventHandlers) OnMintTransactionVoted(fn func(MintTransactionVoted)) {
+	h.On("TFTBridgeModule_MintTransactionVoted", func(event interface{}) { fn(event.(MintTransactionVoted)) })
+}
+
+// OnMintCompleted registers a handler for TFTBridgeModule.MintCompleted events
+func (h *EventHandlers) OnMintCompleted(fn func(MintCompleted)) {
+	h.On("TFTBridgeModule_MintCompleted", func(event interface{}) { fn(event.(MintCompleted)) })
+}
+
+// OnMintTransactionExpired registers a handler for TFTBridgeModule.MintTransactionExpired events
+func (h *EventHandlers) OnMintTransactionExpired(fn func(MintTransactionExpired)) {
+	h.On("TFTBridgeModule_MintTransactionExpired", func(event interface{}) { fn(event.(MintTransactionExpired)) })
+}
+
+// OnBridgeBurnTransactionCreated registers a handler for TFTBridgeModule.BurnTransactionCreated events
+func (h *EventHandlers) OnBridgeBurnTransactionCreated(fn func(BridgeBurnTransactionCreated)) {
+	h.On("TFTBridgeModule_BurnTransactionCreated", func(event interface{}) { fn(event.(BridgeBurnTransactionCreated)) })
+}
+
+// OnBurnTransactionProposed registers a handler for TFTBridgeModule.BurnTransactionProposed events
+func (h *EventHandlers) OnBurnTransactionProposed(fn func(BurnTransactionProposed)) {
+	h.On("TFTBridgeModule_BurnTransactionProposed", func(event interface{}) { fn(event.(BurnTransactionProposed)) })
+}
+
+// OnBurnTransactionSignatureAdded registers a handler for TFTBridgeModule.BurnTransactionSignatureAdded events
+func (h *EventHandlers) OnBurnTransactionSignatureAdded(fn func(BurnTransactionSignatureAdded)) {
+	h.On("TFTBridgeModule_BurnTransactionSignatureAdded", func(event interface{}) { fn(event.(BurnTransactionSignatureAdded)) })
+}
+
+// OnBurnTransactionReady registers a handler for TFTBridgeModule.BurnTransactionReady events
+func (h *EventHandlers) OnBurnTransactionReady(fn func(BurnTransactionReady)) {
+	h.On("TFTBridgeModule_BurnTransactionReady", func(event interface{}) { fn(event.(BurnTransactionReady)) })
+}
+
+// OnBurnTransactionProcessed registers a handler for TFTBridgeModule.BurnTransactionProcessed events
+func (h *EventHandlers) OnBurnTransactionProcessed(fn func(BurnTransactionProcessed)) {
+	h.On("TFTBridgeModule_BurnTransactionProcessed", func(event interface{}) { fn(event.(BurnTransactionProcessed)) })
+}
+
+// OnBurnTransactionExpired registers a handler for TFTBridgeModule.BurnTransactionExpired events
+func (h *EventHandlers) OnBurnTransactionExpired(fn func(BridgeBurnTransactionExpired)) {
+	h.On("TFTBridgeModule_BurnTransactionExpired", func(event interface{}) { fn(event.(BridgeBurnTransactionExpired)) })
+}
+
+// OnRefundTransactionCreated registers a handler for TFTBridgeModule.RefundTransactionCreated events
+func (h *EventHandlers) OnRefundTransactionCreated(fn func(RefundTransactionCreated)) {
+	h.On("TFTBridgeModule_RefundTransactionCreated", func(event interface{}) { fn(event.(RefundTransactionCreated)) })
+}
+
+// OnRefundTransactionSignatureAdded registers a handler for TFTBridgeModule.RefundTransactionsignatureAdded events
+func (h *EventHandlers) OnRefundTransactionSignatureAdded(fn func(RefundTransactionSignatureAdded)) {
+	h.On("TFTBridgeModule_RefundTransactionsignatureAdded", func(event interface{}) { fn(event.(RefundTransactionSignatureAdded)) })
+}
+
+// OnRefundTransactionReady registers a handler for TFTBridgeModule.RefundTransactionReady events
+func (h *EventHandlers) OnRefundTransactionReady(fn func(RefundTransactionReady)) {
+	h.On("TFTBridgeModule_RefundTransactionReady", func(event interface{}) { fn(event.(RefundTransactionReady)) })
+}
+
+// OnRefundTransactionProcessed registers a handler for TFTBridgeModule.RefundTransactionProcessed events
+func (h *EventHandlers) OnRefundTransactionProcessed(fn func(RefundTransactionProcessed)) {
+	h.On("TFTBridgeModule_RefundTransactionProcessed", func(event interface{}) { fn(event.(RefundTransactionProcessed)) })
+}
+
+// OnRefundTransactionExpired registers a handler for TFTBridgeModule.RefundTransactionExpired events
+func (h *EventHandlers) OnRefundTransactionExpired(fn func(RefundTransactionCreated)) {
+	h.On("TFTBridgeModule_RefundTransactionExpired", func(event interface{}) { fn(event.(RefundTransactionCreated)) })
+}
+
+// OnPriceStored registers a handler for TFTPriceModule.PriceStored events
+func (h *EventHandlers) OnPriceStored(fn func(PriceStored)) {
+	h.On("TFTPriceModule_PriceStored", func(event interface{}) { fn(event.(PriceStored)) })
+}
+
+// OnAveragePriceStored registers a handler for TFTPriceModule.AveragePriceStored events
+func (h *EventHandlers) OnAveragePriceStored(fn func(PriceStored)) {
+	h.On("TFTPriceModule_AveragePriceStored", func(event interface{}) { fn(event.(PriceStored)) })
+}
+
+// OnOffchainWorkerExecuted registers a handler for TFTPriceModule.OffchainWorkerExecuted events
+func (h *EventHandlers) OnOffchainWorkerExecuted(fn func(OffchainWorkerExecuted)) {
+	h.On("TFTPriceModule_OffchainWorkerExecuted", func(event interface{}) { fn(event.(OffchainWorkerExecuted)) })
+}
+
+// OnAveragePriceIsAboveMaxPrice registers a handler for TFTPriceModule.AveragePriceIsAboveMaxPrice events
+func (h *EventHandlers) OnAveragePriceIsAboveMaxPrice(fn func(AveragePriceIsAboveMaxPrice)) {
+	h.On("TFTPriceModule_AveragePriceIsAboveMaxPrice", func(event interface{}) { fn(event.(AveragePriceIsAboveMaxPrice)) })
+}
+
+// OnAveragePriceIsBelowMinPrice registers a handler for TFTPriceModule.AveragePriceIsBelowMinPrice events
+func (h *EventHandlers) OnAveragePriceIsBelowMinPrice(fn func(AveragePriceIsAboveMinPrice)) {
+	h.On("TFTPriceModule_AveragePriceIsBelowMinPrice", func(event interface{}) { fn(event.(AveragePriceIsAboveMinPrice)) })
+}
+
+// OnEntrySet registers a handler for TFKVStore.EntrySet events
+func (h *EventHandlers) OnEntrySet(fn func(EntryEvent)) {
+	h.On("TFKVStore_EntrySet", func(event interface{}) { fn(event.(EntryEvent)) })
+}
+
+// OnEntryGot registers a handler for TFKVStore.EntryGot events
+func (h *EventHandlers) OnEntryGot(fn func(EntryEvent)) {
+	h.On("TFKVStore_EntryGot", func(event interface{}) { fn(event.(EntryEvent)) })
+}
+
+// OnEntryTaken registers a handler for TFKVStore.EntryTaken events
+func (h *EventHandlers) OnEntryTaken(fn func(EntryEvent)) {
+	h.On("TFKVStore_EntryTaken", func(event interface{}) { fn(event.(EntryEvent)) })
+}
+
+// OnValidatorAdditionInitiated registers a handler for ValidatorSet.ValidatorAdditionInitiated events
+func (h *EventHandlers) OnValidatorAdditionInitiated(fn func(ValidatorAdded)) {
+	h.On("ValidatorSet_ValidatorAdditionInitiated", func(event interface{}) { fn(event.(ValidatorAdded)) })
+}
+
+// OnValidatorRemovalInitiated registers a handler for ValidatorSet.ValidatorRemovalInitiated events
+func (h *EventHandlers) OnValidatorRemovalInitiated(fn func(ValidatorRemoved)) {
+	h.On("ValidatorSet_ValidatorRemovalInitiated", func(event interface{}) { fn(event.(ValidatorRemoved)) })
+}
+
+// OnBonded registers a handler for Validator.Bonded events
+func (h *EventHandlers) OnBonded(fn func(Bonded)) {
+	h.On("Validator_Bonded", func(event interface{}) { fn(event.(Bonded)) })
+}
+
+// OnValidatorRequestCreated registers a handler for Validator.ValidatorRequestCreated events
+func (h *EventHandlers) OnValidatorRequestCreated(fn func(ValidatorCreated)) {
+	h.On("Validator_ValidatorRequestCreated", func(event interface{}) { fn(event.(ValidatorCreated)) })
+}
+
+// OnValidatorRequestApproved registers a handler for Validator.ValidatorRequestApproved events
+func (h *EventHandlers) OnValidatorRequestApproved(fn func(ValidatorApproved)) {
+	h.On("Validator_ValidatorRequestApproved", func(event interface{}) { fn(event.(ValidatorApproved)) })
+}
+
+// OnValidatorActivated registers a handler for Validator.ValidatorActivated events
+func (h *EventHandlers) OnValidatorActivated(fn func(ValidatorApproved)) {
+	h.On("Validator_ValidatorActivated", func(event interface{}) { fn(event.(ValidatorApproved)) })
+}
+
+// OnValidatorRemoved registers a handler for Validator.ValidatorRemoved events
+func (h *EventHandlers) OnValidatorRemoved(fn func(ValidatorApproved)) {
+	h.On("Validator_ValidatorRemoved", func(event interface{}) { fn(event.(ValidatorApproved)) })
+}
+
+// OnNodeValidatorChanged registers a handler for Validator.NodeValidatorChanged events
+func (h *EventHandlers) OnNodeValidatorChanged(fn func(Bonded)) {
+	h.On("Validator_NodeValidatorChanged", func(event interface{}) { fn(event.(Bonded)) })
+}
+
+// OnNodeValidatorRemoved registers a handler for Validator.NodeValidatorRemoved events
+func (h *EventHandlers) OnNodeValidatorRemoved(fn func(Bonded)) {
+	h.On("Validator_NodeValidatorRemoved", func(event interface{}) { fn(event.(Bonded)) })
+}
+
+// OnMemberAdded registers a handler for CouncilMembership.MemberAdded events
+func (h *EventHandlers) OnMemberAdded(fn func(MemberEvent)) {
+	h.On("CouncilMembership_MemberAdded", func(event interface{}) { fn(event.(MemberEvent)) })
+}
+
+// OnMemberRemoved registers a handler for CouncilMembership.MemberRemoved events
+func (h *EventHandlers) OnMemberRemoved(fn func(MemberEvent)) {
+	h.On("CouncilMembership_MemberRemoved", func(event interface{}) { fn(event.(MemberEvent)) })
+}
+
+// OnMembersSwapped registers a handler for CouncilMembership.MembersSwapped events
+func (h *EventHandlers) OnMembersSwapped(fn func(MemberEvent)) {
+	h.On("CouncilMembership_MembersSwapped", func(event interface{}) { fn(event.(MemberEvent)) })
+}
+
+// OnMembersReset registers a handler for CouncilMembership.MembersReset events
+func (h *EventHandlers) OnMembersReset(fn func(MemberEvent)) {
+	h.On("CouncilMembership_MembersReset", func(event interface{}) { fn(event.(MemberEvent)) })
+}
+
+// OnKeyChanged registers a handler for CouncilMembership.KeyChanged events
+func (h *EventHandlers) OnKeyChanged(fn func(MemberEvent)) {
+	h.On("CouncilMembership_KeyChanged", func(event interface{}) { fn(event.(MemberEvent)) })
+}
+
+// OnDummy registers a handler for CouncilMembership.Dummy events
+func (h *EventHandlers) OnDummy(fn func(MemberEvent)) {
+	h.On("CouncilMembership_Dummy", func(event interface{}) { fn(event.(MemberEvent)) })
+}
+
+// OnVoted registers a handler for Dao.Voted events
+func (h *EventHandlers) OnVoted(fn func(Voted)) {
+	h.On("Dao_Voted", func(event interface{}) { fn(event.(Voted)) })
+}
+
+// OnProposed registers a handler for Dao.Proposed events
+func (h *EventHandlers) OnProposed(fn func(Proposed)) {
+	h.On("Dao_Proposed", func(event interface{}) { fn(event.(Proposed)) })
+}
+
+// OnApproved registers a handler for Dao.Approved events
+func (h *EventHandlers) OnApproved(fn func(Approved)) {
+	h.On("Dao_Approved", func(event interface{}) { fn(event.(Approved)) })
+}
+
+// OnDisapproved registers a handler for Dao.Disapproved events
+func (h *EventHandlers) OnDisapproved(fn func(Disapproved)) {
+	h.On("Dao_Disapproved", func(event interface{}) { fn(event.(Disapproved)) })
+}
+
+// OnExecuted registers a handler for Dao.Executed events
+func (h *EventHandlers) OnExecuted(fn func(Executed)) {
+	h.On("Dao_Executed", func(event interface{}) { fn(event.(Executed)) })
+}
+
+// OnClosed registers a handler for Dao.Closed events
+func (h *EventHandlers) OnClosed(fn func(Closed)) {
+	h.On("Dao_Closed", func(event interface{}) { fn(event.(Closed)) })
+}
+
+// OnClosedByCouncil registers a handler for Dao.ClosedByCouncil events
+func (h *EventHandlers) OnClosedByCouncil(fn func(ClosedByCouncil)) {
+	h.On("Dao_ClosedByCouncil", func(event interface{}) { fn(event.(ClosedByCouncil)) })
+}
+
+// OnCouncilMemberVeto registers a handler for Dao.CouncilMemberVeto events
+func (h *EventHandlers) OnCouncilMemberVeto(fn func(CouncilMemberVeto)) {
+	h.On("Dao_CouncilMemberVeto", func(event interface{}) { fn(event.(CouncilMemberVeto)) })
+}
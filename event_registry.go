@@ -0,0 +1,145 @@
+package substrate
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/pkg/errors"
+)
+
+// EventType is the go type of an event for a range of runtime versions. It
+// is used to decode events of old blocks, whose layout changed since
+type EventType struct {
+	Pallet string
+	Event  string
+	// MinSpec and MaxSpec are the first and last runtime spec versions
+	// emitting the event with this layout, a zero MaxSpec has no upper bound
+	MinSpec uint32
+	MaxSpec uint32
+	// Type of the event, a struct with types.Phase as first field and
+	// []types.Hash topics as last field
+	Type reflect.Type
+	// Upgrade converts a decoded event of Type to the type of the matching
+	// EventRecords field, so handlers only deal with the latest layout.
+	// It is required if Type is not the EventRecords field type
+	Upgrade func(event interface{}) interface{}
+}
+
+func (t *EventType) matches(spec uint32) bool {
+	return spec >= t.MinSpec && (t.MaxSpec == 0 || spec <= t.MaxSpec)
+}
+
+// EventRegistry maps events of runtime versions to go types
+type EventRegistry struct {
+	m     sync.RWMutex
+	types map[string][]EventType
+}
+
+// DefaultEventRegistry is the registry used to decode events. Layouts of
+// events that changed are registered here, events not found in the registry
+// are decoded with the type of their EventRecords field. It comes with the
+// older layouts of the contract and node events
+var DefaultEventRegistry = newDefaultEventRegistry()
+
+func newDefaultEventRegistry() *EventRegistry {
+	registry := NewEventRegistry()
+	if err := registry.Register(legacyEventTypes...); err != nil {
+		panic(err)
+	}
+
+	return registry
+}
+
+// NewEventRegistry creates an empty event registry
+func NewEventRegistry() *EventRegistry {
+	return &EventRegistry{types: make(map[string][]EventType)}
+}
+
+// Register adds event types to the registry
+func (r *EventRegistry) Register(eventTypes ...EventType) error {
+	records := reflect.TypeOf(EventRecords{})
+	for _, t := range eventTypes {
+		name := fmt.Sprintf("%s_%s", t.Pallet, t.Event)
+		field, ok := records.FieldByName(name)
+		if !ok || field.Type.Kind() != reflect.Slice {
+			return fmt.Errorf("no EventRecords field for event %s", name)
+		}
+
+		if err := checkEventType(t.Type); err != nil {
+			return errors.Wrapf(err, "invalid type for event %s", name)
+		}
+
+		if t.Upgrade == nil && t.Type != field.Type.Elem() {
+			return fmt.Errorf("event %s of type %v requires an upgrade to %v", name, t.Type, field.Type.Elem())
+		}
+
+		if t.MaxSpec != 0 && t.MaxSpec < t.MinSpec {
+			return fmt.Errorf("invalid spec versions range %d-%d for event %s", t.MinSpec, t.MaxSpec, name)
+		}
+	}
+
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	for _, t := range eventTypes {
+		name := fmt.Sprintf("%s_%s", t.Pallet, t.Event)
+		r.types[name] = append(r.types[name], t)
+	}
+
+	return nil
+}
+
+// Lookup returns the type registered for an event at a runtime spec version
+func (r *EventRegistry) Lookup(spec uint32, pallet, event string) (EventType, bool) {
+	r.m.RLock()
+	defer r.m.RUnlock()
+
+	for _, t := range r.types[fmt.Sprintf("%s_%s", pallet, event)] {
+		if t.matches(spec) {
+			return t, true
+		}
+	}
+
+	return EventType{}, false
+}
+
+// layouts returns all types registered for an event, starting with the type
+// of the spec version if any
+func (r *EventRegistry) layouts(spec uint32, pallet, event string) []EventType {
+	r.m.RLock()
+	defer r.m.RUnlock()
+
+	var matching, others []EventType
+	for _, t := range r.types[fmt.Sprintf("%s_%s", pallet, event)] {
+		if t.matches(spec) {
+			matching = append(matching, t)
+		} else {
+			others = append(others, t)
+		}
+	}
+
+	return append(matching, others...)
+}
+
+// checkEventType makes sure typ is an event struct with phase and topics
+func checkEventType(typ reflect.Type) error {
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return fmt.Errorf("expected a struct type, got %v", typ)
+	}
+
+	if typ.NumField() < 2 {
+		return fmt.Errorf("expected at least 2 fields (for Phase and Topics), but has %d fields", typ.NumField())
+	}
+
+	if typ.Field(0).Type != reflect.TypeOf(types.Phase{}) {
+		return fmt.Errorf("expected the first field to be of type types.Phase, but got %v", typ.Field(0).Type)
+	}
+
+	if typ.Field(typ.NumField()-1).Type != reflect.TypeOf([]types.Hash{}) {
+		return fmt.Errorf("expected the last field to be of type []types.Hash, but got %v", typ.Field(typ.NumField()-1).Type)
+	}
+
+	return nil
+}
@@ -0,0 +1,465 @@
+package substrate
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/require"
+)
+
+// legacyItemCompleted is a made up older layout of Utility.ItemCompleted
+// with an extra field
+type legacyItemCompleted struct {
+	Phase  types.Phase
+	Index  types.U8
+	Topics []types.Hash
+}
+
+func TestEventRegistryLookup(t *testing.T) {
+	registry := NewEventRegistry()
+	require.NoError(t, registry.Register(
+		EventType{
+			Pallet:  "Utility",
+			Event:   "ItemCompleted",
+			MinSpec: 1,
+			MaxSpec: 9,
+			Type:    reflect.TypeOf(legacyItemCompleted{}),
+			Upgrade: func(event interface{}) interface{} {
+				return types.EventUtilityItemCompleted{Phase: event.(legacyItemCompleted).Phase}
+			},
+		},
+		EventType{
+			Pallet:  "Utility",
+			Event:   "ItemCompleted",
+			MinSpec: 10,
+			Type:    reflect.TypeOf(types.EventUtilityItemCompleted{}),
+		},
+	))
+
+	typ, ok := registry.Lookup(5, "Utility", "ItemCompleted")
+	require.True(t, ok)
+	require.Equal(t, reflect.TypeOf(legacyItemCompleted{}), typ.Type)
+
+	typ, ok = registry.Lookup(100, "Utility", "ItemCompleted")
+	require.True(t, ok)
+	require.Equal(t, reflect.TypeOf(types.EventUtilityItemCompleted{}), typ.Type)
+
+	_, ok = registry.Lookup(0, "Utility", "ItemCompleted")
+	require.False(t, ok)
+	_, ok = registry.Lookup(5, "Utility", "BatchCompleted")
+	require.False(t, ok)
+
+	// no EventRecords field
+	require.Error(t, registry.Register(EventType{Pallet: "Unknown", Event: "Event", Type: reflect.TypeOf(legacyItemCompleted{})}))
+	// different type without upgrade
+	require.Error(t, registry.Register(EventType{Pallet: "Utility", Event: "ItemCompleted", Type: reflect.TypeOf(legacyItemCompleted{})}))
+	// not an event type
+	require.Error(t, registry.Register(EventType{Pallet: "Utility", Event: "ItemCompleted", Type: reflect.TypeOf(types.U8(0))}))
+}
+
+// describeType adds the metadata type of t to meta, the way the runtime
+// describes its types, and returns its id
+func describeType(meta *types.Metadata, t reflect.Type) types.Si1LookupTypeID {
+	lookup := meta.AsMetadataV14.EfficientLookup
+	add := func(def types.Si1TypeDef) types.Si1LookupTypeID {
+		id := int64(len(lookup))
+		for lookup[id] != nil {
+			id++
+		}
+		lookup[id] = &types.Si1Type{Def: def}
+		return types.NewSi1LookupTypeIDFromUInt(uint64(id))
+	}
+	primitive := func(p types.Si0TypeDefPrimitive) types.Si1LookupTypeID {
+		return add(types.Si1TypeDef{IsPrimitive: true, Primitive: types.Si1TypeDefPrimitive{Si0TypeDefPrimitive: p}})
+	}
+	option := func(value reflect.Type) types.Si1LookupTypeID {
+		return add(types.Si1TypeDef{IsVariant: true, Variant: types.Si1TypeDefVariant{Variants: []types.Si1Variant{
+			{Name: "None", Index: 0},
+			{Name: "Some", Index: 1, Fields: []types.Si1Field{{Type: describeType(meta, value)}}},
+		}}})
+	}
+
+	switch t {
+	case reflect.TypeOf(types.U128{}):
+		return primitive(types.IsU128)
+	case reflect.TypeOf(types.OptionU64{}):
+		return option(reflect.TypeOf(types.U64(0)))
+	case reflect.TypeOf(types.OptionU32{}):
+		return option(reflect.TypeOf(types.U32(0)))
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return primitive(types.IsBool)
+	case reflect.String:
+		return primitive(types.IsStr)
+	case reflect.Uint8:
+		return primitive(types.IsU8)
+	case reflect.Uint16:
+		return primitive(types.IsU16)
+	case reflect.Uint32:
+		return primitive(types.IsU32)
+	case reflect.Uint64:
+		return primitive(types.IsU64)
+	case reflect.Slice:
+		return add(types.Si1TypeDef{IsSequence: true, Sequence: types.Si1TypeDefSequence{Type: describeType(meta, t.Elem())}})
+	case reflect.Array:
+		return add(types.Si1TypeDef{IsArray: true, Array: types.Si1TypeDefArray{Len: types.U32(t.Len()), Type: describeType(meta, t.Elem())}})
+	case reflect.Struct:
+		if isOption(t) {
+			value, _ := t.FieldByName("AsValue")
+			return option(value.Type)
+		}
+
+		if variants, ok := enumVariants(t); ok {
+			var def types.Si1TypeDefVariant
+			for i, variant := range variants {
+				v := types.Si1Variant{Name: types.Text(variant.name), Index: types.U8(i)}
+				for _, j := range variant.value {
+					v.Fields = append(v.Fields, types.Si1Field{Type: describeType(meta, t.Field(j).Type)})
+				}
+				def.Variants = append(def.Variants, v)
+			}
+			return add(types.Si1TypeDef{IsVariant: true, Variant: def})
+		}
+
+		var def types.Si1TypeDefComposite
+		for i := 0; i < t.NumField(); i++ {
+			def.Fields = append(def.Fields, types.Si1Field{HasName: true, Name: types.Text(t.Field(i).Name), Type: describeType(meta, t.Field(i).Type)})
+		}
+		return add(types.Si1TypeDef{IsComposite: true, Composite: def})
+	}
+
+	panic(fmt.Sprintf("no metadata type for %v", t))
+}
+
+// describeEvent sets the fields of an event of meta to the fields of the
+// event struct typ, without its phase and topics
+func describeEvent(t *testing.T, meta *types.Metadata, pallet, event string, typ reflect.Type) {
+	for _, p := range meta.AsMetadataV14.Pallets {
+		if string(p.Name) != pallet {
+			continue
+		}
+
+		variants := meta.AsMetadataV14.EfficientLookup[p.Events.Type.Int64()].Def.Variant.Variants
+		for i := range variants {
+			if string(variants[i].Name) != event {
+				continue
+			}
+
+			variants[i].Fields = nil
+			for j := 1; j < typ.NumField()-1; j++ {
+				variants[i].Fields = append(variants[i].Fields, types.Si1Field{Type: describeType(meta, typ.Field(j).Type)})
+			}
+			return
+		}
+	}
+
+	t.Fatalf("event %s.%s not found in metadata", pallet, event)
+}
+
+func TestDecodeEventsWithRegistry(t *testing.T) {
+	defer func(registry *EventRegistry) { DefaultEventRegistry = registry }(DefaultEventRegistry)
+	DefaultEventRegistry = NewEventRegistry()
+
+	require.NoError(t, DefaultEventRegistry.Register(EventType{
+		Pallet:  "Utility",
+		Event:   "ItemCompleted",
+		MinSpec: 1,
+		MaxSpec: 9,
+		Type:    reflect.TypeOf(legacyItemCompleted{}),
+		Upgrade: func(event interface{}) interface{} {
+			return types.EventUtilityItemCompleted{Phase: event.(legacyItemCompleted).Phase}
+		},
+	}))
+
+	var meta, legacyMeta types.Metadata
+	require.NoError(t, types.DecodeFromHex(types.MetadataV14Data, &meta))
+	require.NoError(t, types.DecodeFromHex(types.MetadataV14Data, &legacyMeta))
+	describeEvent(t, &legacyMeta, "Utility", "ItemCompleted", reflect.TypeOf(legacyItemCompleted{}))
+
+	phase := []byte{0, 2, 0, 0, 0}
+	// Utility.ItemCompleted with the legacy index field, then Utility.BatchCompleted
+	legacy := []byte{2 << 2}
+	legacy = append(legacy, phase...)
+	legacy = append(legacy, 1, 2, 7, 0)
+	legacy = append(legacy, phase...)
+	legacy = append(legacy, 1, 1, 0)
+
+	var events EventRecords
+	require.NoError(t, decodeEventRecords(&legacyMeta, 5, legacy, &events))
+	require.Len(t, events.Utility_ItemCompleted, 1)
+	require.Equal(t, uint32(2), events.Utility_ItemCompleted[0].Phase.AsApplyExtrinsic)
+	require.Len(t, events.Utility_BatchCompleted, 1)
+
+	// the runtime metadata picks the legacy layout out of its spec range
+	events = EventRecords{}
+	require.NoError(t, decodeEventRecords(&legacyMeta, 10, legacy, &events))
+	require.Len(t, events.Utility_ItemCompleted, 1)
+	require.Len(t, events.Utility_BatchCompleted, 1)
+
+	// no layout matches the runtime metadata
+	require.Error(t, decodeEventRecords(&meta, 5, legacy, &EventRecords{}))
+
+	latest := []byte{1 << 2}
+	latest = append(latest, phase...)
+	latest = append(latest, 1, 2, 0)
+
+	for _, spec := range []uint32{5, 10} {
+		events = EventRecords{}
+		require.NoError(t, decodeEventRecords(&meta, spec, latest, &events))
+		require.Len(t, events.Utility_ItemCompleted, 1)
+	}
+}
+
+// gridEventsMetadata is a metadata with the contract and node events of
+// the node and contract event layouts, the pallet and event indexes are
+// arbitrary
+func gridEventsMetadata(t *testing.T, node, contract reflect.Type) *types.Metadata {
+	variants := func(names ...string) *types.Si1Type {
+		typ := &types.Si1Type{Def: types.Si1TypeDef{IsVariant: true}}
+		for i, name := range names {
+			typ.Def.Variant.Variants = append(typ.Def.Variant.Variants, types.Si1Variant{Name: types.Text(name), Index: types.U8(i)})
+		}
+		return typ
+	}
+
+	meta := &types.Metadata{
+		Version: 14,
+		AsMetadataV14: types.MetadataV14{
+			Pallets: []types.PalletMetadataV14{
+				{Name: "TfgridModule", Index: 1, HasEvents: true, Events: types.EventMetadataV14{Type: types.NewSi1LookupTypeIDFromUInt(1)}},
+				{Name: "SmartContractModule", Index: 2, HasEvents: true, Events: types.EventMetadataV14{Type: types.NewSi1LookupTypeIDFromUInt(2)}},
+			},
+			EfficientLookup: map[int64]*types.Si1Type{
+				1: variants("NodeStored", "NodeUpdated"),
+				2: variants("ContractCreated", "ContractUpdated"),
+			},
+		},
+	}
+
+	describeEvent(t, meta, "TfgridModule", "NodeStored", node)
+	describeEvent(t, meta, "TfgridModule", "NodeUpdated", node)
+	describeEvent(t, meta, "SmartContractModule", "ContractCreated", contract)
+	describeEvent(t, meta, "SmartContractModule", "ContractUpdated", contract)
+
+	return meta
+}
+
+// encodeEvents encodes events of the given ids as System.Events
+func encodeEvents(t *testing.T, events ...interface{}) []byte {
+	raw := []byte{byte(len(events) / 2 << 2)}
+	for i := 0; i < len(events); i += 2 {
+		// phase ApplyExtrinsic(1), then the event id and fields
+		raw = append(raw, 0, 1, 0, 0, 0)
+		raw = append(raw, events[i].([]byte)...)
+
+		data, err := types.Encode(events[i+1])
+		require.NoError(t, err)
+		// drop the phase of the event struct
+		raw = append(raw, data[5:]...)
+	}
+
+	return raw
+}
+
+var (
+	legacyPhase = types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: 1}
+
+	legacyContract = ContractV1{
+		Versioned:  Versioned{Version: 3},
+		State:      ContractState{IsCreated: true},
+		ContractID: 12,
+		TwinID:     7,
+		ContractType: ContractType{
+			IsNameContract: true,
+			NameContract:   NameContract{Name: "example"},
+		},
+	}
+
+	legacyNodeV1 = NodeV1{
+		Versioned: Versioned{Version: 4},
+		ID:        3,
+		FarmID:    1,
+		TwinID:    7,
+		Location:  LocationV1{Longitude: "4.35", Latitude: "50.85"},
+		Country:   "Belgium",
+		City:      "Brussels",
+		PublicConfig: OptionPublicConfigV1{HasValue: true, AsValue: PublicConfigV1{
+			IPv4: "185.206.122.33/24",
+			GWv4: "185.206.122.1",
+		}},
+		Interfaces:    []Interface{{Name: "zos", Mac: "00:11:22:33:44:55", IPs: []string{"10.0.0.2"}}},
+		Certification: NodeCertification{IsDiy: true},
+		SecureBoot:    true,
+		BoardSerial:   "serial",
+	}
+
+	legacyNodeV2 = NodeV2{
+		Versioned: Versioned{Version: 5},
+		ID:        4,
+		FarmID:    1,
+		TwinID:    8,
+		Location:  LocationV1{Longitude: "4.35", Latitude: "50.85"},
+		Country:   "Belgium",
+		City:      "Brussels",
+		PublicConfig: OptionPublicConfig{HasValue: true, AsValue: PublicConfig{
+			IP4:    IP{IP: "185.206.122.34/24", GW: "185.206.122.1"},
+			Domain: OptionDomain{HasValue: true, AsValue: "node.grid.tf"},
+		}},
+		Certification: NodeCertification{IsCertified: true},
+	}
+
+	latestNode = Node{
+		Versioned:     Versioned{Version: 6},
+		ID:            5,
+		Location:      Location{City: "Ghent", Country: "Belgium"},
+		BoardSerial:   OptionBoardSerial{HasValue: true, AsValue: "serial"},
+		PublicConfig:  legacyNodeV2.PublicConfig,
+		Certification: NodeCertification{IsDiy: true},
+	}
+
+	latestContract = Contract{
+		Versioned:          Versioned{Version: 4},
+		State:              ContractState{IsCreated: true},
+		ContractID:         13,
+		TwinID:             7,
+		ContractType:       ContractType{IsRentContract: true, RentContract: RentContract{Node: 5}},
+		SolutionProviderID: types.NewOptionU64(2),
+	}
+)
+
+func TestDecodeLegacyGridEvents(t *testing.T) {
+	meta := gridEventsMetadata(t, reflect.TypeOf(NodeStoredV1{}), reflect.TypeOf(ContractCreatedV1{}))
+	raw := encodeEvents(t,
+		[]byte{2, 0}, ContractCreatedV1{Phase: legacyPhase, Contract: legacyContract},
+		[]byte{2, 1}, ContractUpdatedV1{Phase: legacyPhase, Contract: legacyContract},
+		[]byte{1, 0}, NodeStoredV1{Phase: legacyPhase, Node: legacyNodeV1},
+	)
+
+	var events EventRecords
+	require.NoError(t, decodeEventRecords(meta, specContractSolutionProvider-1, raw, &events))
+
+	require.Len(t, events.SmartContractModule_ContractCreated, 1)
+	created := events.SmartContractModule_ContractCreated[0].Contract
+	require.Equal(t, types.U64(12), created.ContractID)
+	require.Equal(t, "example", created.ContractType.NameContract.Name)
+	hasProvider, _ := created.SolutionProviderID.Unwrap()
+	require.False(t, hasProvider)
+	require.Len(t, events.SmartContractModule_ContractUpdated, 1)
+
+	require.Len(t, events.TfgridModule_NodeStored, 1)
+	node := events.TfgridModule_NodeStored[0].Node
+	require.Equal(t, types.U32(3), node.ID)
+	require.Equal(t, Location{City: "Brussels", Country: "Belgium", Latitude: "50.85", Longitude: "4.35"}, node.Location)
+	require.Equal(t, IP{IP: "185.206.122.33/24", GW: "185.206.122.1"}, node.PublicConfig.AsValue.IP4)
+	require.False(t, node.PublicConfig.AsValue.IP6.HasValue)
+	require.False(t, node.PublicConfig.AsValue.Domain.HasValue)
+	require.Equal(t, OptionBoardSerial{HasValue: true, AsValue: "serial"}, node.BoardSerial)
+	require.True(t, node.SecureBoot)
+	require.Len(t, node.Interfaces, 1)
+
+	meta = gridEventsMetadata(t, reflect.TypeOf(NodeStoredV2{}), reflect.TypeOf(ContractCreated{}))
+	raw = encodeEvents(t, []byte{1, 1}, NodeStoredV2{Phase: legacyPhase, Node: legacyNodeV2})
+	events = EventRecords{}
+	require.NoError(t, decodeEventRecords(meta, specNodePublicConfigIPs, raw, &events))
+	require.Len(t, events.TfgridModule_NodeUpdated, 1)
+	node = events.TfgridModule_NodeUpdated[0].Node
+	require.Equal(t, types.U32(4), node.ID)
+	require.Equal(t, "Brussels", node.Location.City)
+	require.Equal(t, legacyNodeV2.PublicConfig, node.PublicConfig)
+	require.False(t, node.BoardSerial.HasValue)
+
+	meta = gridEventsMetadata(t, reflect.TypeOf(NodeStored{}), reflect.TypeOf(ContractCreated{}))
+	raw = encodeEvents(t, []byte{1, 0}, NodeStored{Phase: legacyPhase, Node: latestNode})
+	events = EventRecords{}
+	require.NoError(t, decodeEventRecords(meta, specNodeLocation, raw, &events))
+	require.Len(t, events.TfgridModule_NodeStored, 1)
+	require.Equal(t, latestNode, events.TfgridModule_NodeStored[0].Node)
+}
+
+// TestDecodeGridEventsAtSpecBoundaries decodes the events of the last spec
+// before and the first spec after every layout change, with the metadata of
+// the runtime at that spec
+func TestDecodeGridEventsAtSpecBoundaries(t *testing.T) {
+	contractV1 := ContractCreatedV1{Phase: legacyPhase, Contract: legacyContract}
+	contract := ContractCreated{Phase: legacyPhase, Contract: latestContract}
+	nodeV1 := NodeStoredV1{Phase: legacyPhase, Node: legacyNodeV1}
+	nodeV2 := NodeStoredV2{Phase: legacyPhase, Node: legacyNodeV2}
+	node := NodeStored{Phase: legacyPhase, Node: latestNode}
+
+	for _, tc := range []struct {
+		spec           uint32
+		node, contract interface{}
+	}{
+		{specContractSolutionProvider - 1, nodeV1, contractV1},
+		{specContractSolutionProvider, nodeV1, contract},
+		{specNodePublicConfigIPs - 1, nodeV1, contract},
+		{specNodePublicConfigIPs, nodeV2, contract},
+		{specNodeLocation - 1, nodeV2, contract},
+		{specNodeLocation, node, contract},
+	} {
+		meta := gridEventsMetadata(t, reflect.TypeOf(tc.node), reflect.TypeOf(tc.contract))
+		raw := encodeEvents(t, []byte{2, 0}, tc.contract, []byte{1, 0}, tc.node)
+
+		var events EventRecords
+		require.NoError(t, decodeEventRecords(meta, tc.spec, raw, &events), "spec %d", tc.spec)
+		require.Len(t, events.SmartContractModule_ContractCreated, 1)
+		require.Len(t, events.TfgridModule_NodeStored, 1)
+
+		expectedContract := tc.contract
+		if legacy, ok := expectedContract.(ContractCreatedV1); ok {
+			expectedContract = legacy.Upgrade()
+		}
+		require.Equal(t, expectedContract, events.SmartContractModule_ContractCreated[0], "spec %d", tc.spec)
+
+		expectedNode := tc.node
+		switch legacy := expectedNode.(type) {
+		case NodeStoredV1:
+			expectedNode = legacy.Upgrade()
+		case NodeStoredV2:
+			expectedNode = legacy.Upgrade()
+		}
+		require.Equal(t, expectedNode, events.TfgridModule_NodeStored[0], "spec %d", tc.spec)
+	}
+}
+
+func TestDecodeGridEventsOutOfSpecRange(t *testing.T) {
+	// events of a runtime still on the old layouts decoded with a later
+	// spec, as if a boundary was off: the layout of the runtime metadata
+	// is used
+	meta := gridEventsMetadata(t, reflect.TypeOf(NodeStoredV1{}), reflect.TypeOf(ContractCreatedV1{}))
+	raw := encodeEvents(t,
+		[]byte{2, 0}, ContractCreatedV1{Phase: legacyPhase, Contract: legacyContract},
+		[]byte{1, 0}, NodeStoredV1{Phase: legacyPhase, Node: legacyNodeV1},
+	)
+
+	var events EventRecords
+	require.NoError(t, decodeEventRecords(meta, specNodeLocation, raw, &events))
+	require.Equal(t, legacyContract.Upgrade(), events.SmartContractModule_ContractCreated[0].Contract)
+	require.Equal(t, legacyNodeV1.Upgrade(), events.TfgridModule_NodeStored[0].Node)
+
+	// and the other way around
+	meta = gridEventsMetadata(t, reflect.TypeOf(NodeStored{}), reflect.TypeOf(ContractCreated{}))
+	raw = encodeEvents(t, []byte{1, 0}, NodeStored{Phase: legacyPhase, Node: latestNode})
+
+	events = EventRecords{}
+	require.NoError(t, decodeEventRecords(meta, specNodePublicConfigIPs-1, raw, &events))
+	require.Equal(t, latestNode, events.TfgridModule_NodeStored[0].Node)
+
+	// a layout that isn't registered fails instead of being misdecoded
+	type unknownNodeStored struct {
+		Phase  types.Phase
+		Node   Node
+		Extra  types.U32
+		Topics []types.Hash
+	}
+
+	meta = gridEventsMetadata(t, reflect.TypeOf(unknownNodeStored{}), reflect.TypeOf(ContractCreated{}))
+	raw = encodeEvents(t, []byte{1, 0}, unknownNodeStored{Phase: legacyPhase, Node: latestNode, Extra: 1})
+	err := decodeEventRecords(meta, specNodeLocation, raw, &EventRecords{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no layout of the event matches the runtime metadata")
+}
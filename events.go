@@ -313,6 +313,12 @@ type ZosVersionUpdated struct {
 // EventRecords is a struct that extends the default events with our events
 type EventRecords struct {
 	types.EventRecords
+	// Order references all decoded events in the order they were emitted
+	Order []EventRef
+	// Dynamic holds events without a field in EventRecords, decoded with
+	// the runtime metadata types
+	Dynamic []DynamicEvent
+
 	SmartContractModule_ContractCreated              []ContractCreated              //nolint:stylecheck,golint
 	SmartContractModule_ContractUpdated              []ContractUpdated              //nolint:stylecheck,golint
 	SmartContractModule_NodeContractCanceled         []NodeContractCanceled         //nolint:stylecheck,golint
@@ -0,0 +1,271 @@
+package substrate
+
+import (
+	"bytes"
+	"context"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultRangeChunkSize   = 100
+	defaultRangeConcurrency = 4
+)
+
+// RangeOptions configures block range queries and ID range scans
+type RangeOptions struct {
+	// ChunkSize is the max number of blocks (or IDs) queried in a single rpc call
+	ChunkSize uint32
+	// Concurrency is the max number of chunks queried in parallel
+	Concurrency int
+}
+
+// RangeOption sets an option of a block range query
+type RangeOption func(*RangeOptions)
+
+// WithChunkSize sets the max number of blocks (or IDs) queried in a single rpc call
+func WithChunkSize(size uint32) RangeOption {
+	return func(o *RangeOptions) {
+		o.ChunkSize = size
+	}
+}
+
+// WithConcurrency sets the max number of parallel rpc calls
+func WithConcurrency(n int) RangeOption {
+	return func(o *RangeOptions) {
+		o.Concurrency = n
+	}
+}
+
+func newRangeOptions(opts []RangeOption) RangeOptions {
+	options := RangeOptions{
+		ChunkSize:   defaultRangeChunkSize,
+		Concurrency: defaultRangeConcurrency,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if options.ChunkSize == 0 {
+		options.ChunkSize = defaultRangeChunkSize
+	}
+
+	if options.Concurrency <= 0 {
+		options.Concurrency = 1
+	}
+
+	return options
+}
+
+// GetEventsForBlockRange gets the decoded events of all blocks from start to
+// end (inclusive), ordered by block number
+func (s *Substrate) GetEventsForBlockRange(start uint32, end uint32, opts ...RangeOption) ([]BlockEvents, error) {
+	return s.GetEventsForBlockRangeCtx(context.Background(), start, end, opts...)
+}
+
+// GetEventsForBlockRangeCtx is like GetEventsForBlockRange but takes a context
+func (s *Substrate) GetEventsForBlockRangeCtx(ctx context.Context, start uint32, end uint32, opts ...RangeOption) ([]BlockEvents, error) {
+	if end < start {
+		return nil, errors.Errorf("invalid block range %d-%d", start, end)
+	}
+
+	options := newRangeOptions(opts)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	cl, _, err := s.getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	chunks := int((end-start)/options.ChunkSize) + 1
+	results := make([][]BlockEvents, chunks)
+
+	var (
+		wg    sync.WaitGroup
+		once  sync.Once
+		first error
+		sem   = make(chan struct{}, options.Concurrency)
+	)
+
+	for i := 0; i < chunks; i++ {
+		from := start + uint32(i)*options.ChunkSize
+		to := from + options.ChunkSize - 1
+		if to > end || to < from {
+			to = end
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(i int, from, to uint32) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			blocks, err := s.eventsChunk(cl, from, to)
+			if err != nil {
+				once.Do(func() {
+					first = err
+					cancel()
+				})
+				return
+			}
+
+			results[i] = blocks
+		}(i, from, to)
+	}
+
+	wg.Wait()
+
+	if first != nil {
+		return nil, first
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	blocks := make([]BlockEvents, 0, end-start+1)
+	for _, chunk := range results {
+		blocks = append(blocks, chunk...)
+	}
+
+	return blocks, nil
+}
+
+// eventsChunk gets the events of blocks from-to with a single storage query
+func (s *Substrate) eventsChunk(cl Conn, from, to uint32) ([]BlockEvents, error) {
+	fromHash, err := cl.RPC.Chain.GetBlockHash(uint64(from))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get block hash of block %d", from)
+	}
+
+	toHash, err := cl.RPC.Chain.GetBlockHash(uint64(to))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get block hash of block %d", to)
+	}
+
+	meta, spec, err := s.metadataAt(cl, fromHash)
+	if err != nil {
+		return nil, err
+	}
+
+	toVersion, err := cl.RPC.State.GetRuntimeVersion(toHash)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get runtime version of block %d", to)
+	}
+
+	// runtime upgrades are rare, metadata is only looked up per block for
+	// the chunk that has one
+	upgraded := spec != uint32(toVersion.SpecVersion)
+
+	eventsKey, err := types.CreateStorageKey(meta, "System", "Events", nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create storage key")
+	}
+
+	timeKey, err := types.CreateStorageKey(meta, "Timestamp", "Now", nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create storage key")
+	}
+
+	sets, err := cl.RPC.State.QueryStorage([]types.StorageKey{eventsKey, timeKey}, fromHash, toHash)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query events of blocks %d-%d", from, to)
+	}
+
+	hashes, err := chunkHashes(cl, from, to, sets)
+	if err != nil {
+		return nil, err
+	}
+
+	bySet := make(map[types.Hash]types.StorageChangeSet, len(sets))
+	for _, set := range sets {
+		bySet[set.Block] = set
+	}
+
+	var (
+		blocks = make([]BlockEvents, 0, len(hashes))
+		events types.StorageDataRaw
+		now    types.StorageDataRaw
+	)
+
+	for i, hash := range hashes {
+		number := from + uint32(i)
+
+		// only changed values are returned, others keep the value
+		// of the previous block
+		for _, change := range bySet[hash].Changes {
+			var value types.StorageDataRaw
+			if change.HasStorageData {
+				value = change.StorageData
+			}
+
+			if bytes.Equal(change.StorageKey, eventsKey) {
+				events = value
+			} else if bytes.Equal(change.StorageKey, timeKey) {
+				now = value
+			}
+		}
+
+		blockMeta, blockSpec := meta, spec
+		if upgraded {
+			if blockMeta, blockSpec, err = s.metadataAt(cl, hash); err != nil {
+				return nil, err
+			}
+		}
+
+		block := BlockEvents{Number: number, Hash: hash, Events: &EventRecords{}}
+		if len(events) != 0 {
+			if err := decodeEventRecords(blockMeta, blockSpec, types.EventRecordsRaw(events), block.Events); err != nil {
+				return nil, errors.Wrapf(err, "failed to decode events of block %d", number)
+			}
+		}
+
+		if len(now) != 0 {
+			if block.Timestamp, err = decodeTimestamp(now); err != nil {
+				return nil, errors.Wrapf(err, "failed to decode time of block %d", number)
+			}
+		}
+
+		blocks = append(blocks, block)
+	}
+
+	return blocks, nil
+}
+
+// chunkHashes returns the hashes of blocks from-to. Timestamp changes every
+// block so there is normally a change set per block, otherwise the hashes
+// are looked up by number
+func chunkHashes(cl Conn, from, to uint32, sets []types.StorageChangeSet) ([]types.Hash, error) {
+	count := int(to-from) + 1
+	hashes := make([]types.Hash, 0, count)
+	if len(sets) == count {
+		for _, set := range sets {
+			hashes = append(hashes, set.Block)
+		}
+
+		return hashes, nil
+	}
+
+	for n := from; n <= to && n >= from; n++ {
+		hash, err := cl.RPC.Chain.GetBlockHash(uint64(n))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get block hash of block %d", n)
+		}
+		hashes = append(hashes, hash)
+	}
+
+	return hashes, nil
+}
@@ -0,0 +1,170 @@
+package substrate
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/require"
+)
+
+// handleQueryStorage serves state_queryStorage for System.Events and
+// Timestamp.Now over the canonical blocks of the chain. Like a real node
+// only changed values are returned, except for the first block
+func handleQueryStorage(t *testing.T, chain *fakeChain) {
+	node := chain.node
+	eventsKey, err := types.CreateStorageKey(node.meta, "System", "Events", nil)
+	require.NoError(t, err)
+	timeKey, err := types.CreateStorageKey(node.meta, "Timestamp", "Now", nil)
+	require.NoError(t, err)
+
+	node.handle("state_queryStorage", func(params []json.RawMessage) (interface{}, error) {
+		var from, to string
+		if err := json.Unmarshal(params[1], &from); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(params[2], &to); err != nil {
+			return nil, err
+		}
+
+		node.m.Lock()
+		defer node.m.Unlock()
+
+		var (
+			sets     []interface{}
+			started  bool
+			previous string
+		)
+		for n := uint32(1); n <= chain.best; n++ {
+			header := chain.blocks[n]
+			hash, err := headerHash(&header)
+			require.NoError(t, err)
+
+			if hash.Hex() == from {
+				started = true
+			}
+			if !started {
+				continue
+			}
+
+			events, ok := chain.events[hash]
+			if !ok {
+				events = []byte{0}
+			}
+
+			stamp, err := types.Encode(types.U64(n * 6000))
+			require.NoError(t, err)
+
+			changes := [][]interface{}{{timeKey.Hex(), types.HexEncodeToString(stamp)}}
+			if value := types.HexEncodeToString(events); value != previous || hash.Hex() == from {
+				changes = append(changes, []interface{}{eventsKey.Hex(), value})
+				previous = value
+			}
+
+			sets = append(sets, map[string]interface{}{"block": hash.Hex(), "changes": changes})
+			if hash.Hex() == to {
+				break
+			}
+		}
+
+		return sets, nil
+	})
+}
+
+func TestGetEventsForBlockRange(t *testing.T) {
+	node := newFakeNode(t)
+	chain := newFakeChain(t, node)
+	handleQueryStorage(t, chain)
+
+	// Utility.ItemCompleted
+	itemCompleted := []byte{1 << 2, 0, 0, 0, 0, 0, 1, 2, 0}
+
+	withEvents := map[uint32]bool{3: true, 4: true, 8: true}
+	for i := uint32(1); i <= 10; i++ {
+		var events []byte
+		if withEvents[i] {
+			events = itemCompleted
+		}
+		chain.add(i, events)
+	}
+
+	cl := testClient(t, node, DefaultManagerOptions())
+
+	blocks, err := cl.GetEventsForBlockRange(2, 10, WithChunkSize(3), WithConcurrency(2))
+	require.NoError(t, err)
+	require.Len(t, blocks, 9)
+	require.Equal(t, 3, node.count("state_queryStorage"))
+
+	for i, block := range blocks {
+		number := uint32(i) + 2
+		require.Equal(t, number, block.Number)
+
+		header := chain.blocks[number]
+		hash, err := headerHash(&header)
+		require.NoError(t, err)
+		require.Equal(t, hash, block.Hash)
+		require.Equal(t, time.Unix(int64(number*6), 0).UTC(), block.Timestamp.UTC())
+
+		if withEvents[number] {
+			require.Len(t, block.Events.Utility_ItemCompleted, 1, "block %d", number)
+		} else {
+			require.Empty(t, block.Events.Utility_ItemCompleted, "block %d", number)
+		}
+	}
+
+	_, err = cl.GetEventsForBlockRange(5, 4)
+	require.Error(t, err)
+}
+
+func TestGetEventsForBlockRangeRuntimeUpgrade(t *testing.T) {
+	node := newFakeNode(t)
+	chain := newFakeChain(t, node)
+	handleQueryStorage(t, chain)
+
+	upgrade := make(map[string]bool)
+	for i := uint32(1); i <= 6; i++ {
+		header := chain.add(i, nil)
+		if i >= 4 {
+			hash, err := headerHash(&header)
+			require.NoError(t, err)
+			upgrade[hash.Hex()] = true
+		}
+	}
+
+	node.handle("state_getRuntimeVersion", func(params []json.RawMessage) (interface{}, error) {
+		spec := types.U32(1)
+		if len(params) > 0 {
+			var block string
+			if err := json.Unmarshal(params[0], &block); err != nil {
+				return nil, err
+			}
+
+			if upgrade[block] {
+				spec = 2
+			}
+		}
+
+		return types.RuntimeVersion{SpecName: "fake", SpecVersion: spec}, nil
+	})
+
+	cl := testClient(t, node, DefaultManagerOptions())
+
+	blocks, err := cl.GetEventsForBlockRange(1, 6, WithChunkSize(6))
+	require.NoError(t, err)
+	require.Len(t, blocks, 6)
+	// metadata of both runtime versions
+	require.Equal(t, 2, node.count("state_getMetadata"))
+}
+
+func TestDecodeTimestamp(t *testing.T) {
+	data, err := types.Encode(types.U64(1666000000123))
+	require.NoError(t, err)
+
+	stamp, err := decodeTimestamp(data)
+	require.NoError(t, err)
+	require.Equal(t, time.Unix(1666000000, 123*int64(time.Millisecond)).UTC(), stamp.UTC())
+
+	_, err = decodeTimestamp([]byte{1, 2})
+	require.Error(t, err)
+}
@@ -0,0 +1,179 @@
+package substrate
+
+import (
+	"context"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
+)
+
+// SubscribeOptions configures an events subscription
+type SubscribeOptions struct {
+	// Finalized follows finalized heads instead of best heads
+	Finalized bool
+	// Buffer size of the returned channel
+	Buffer int
+}
+
+// BlockEvents holds the decoded events of a single block
+type BlockEvents struct {
+	Number     uint32
+	Hash       types.Hash
+	ParentHash types.Hash
+	Timestamp  time.Time
+	Events     *EventRecords
+	// Reverted is set if the block was delivered before but is no longer
+	// part of the canonical chain after a reorganization, its events must
+	// be undone. Events can be nil if they are not available on the node
+	Reverted bool
+	// Err is set if the subscription failed, it is always the last
+	// value sent before the channel is closed
+	Err error
+}
+
+// headSubscription is implemented by both new and finalized heads subscriptions
+type headSubscription interface {
+	Chan() <-chan types.Header
+	Err() <-chan error
+	Unsubscribe()
+}
+
+func subscribeHeads(cl Conn, finalized bool) (sub headSubscription, err error) {
+	if finalized {
+		sub, err = cl.RPC.Chain.SubscribeFinalizedHeads()
+	} else {
+		sub, err = cl.RPC.Chain.SubscribeNewHeads()
+	}
+
+	return sub, errors.Wrap(err, "failed to subscribe to chain heads")
+}
+
+// SubscribeEvents follows new best (or finalized) heads and sends the decoded
+// events of each block on the returned channel. Blocks skipped by the node
+// between two heads are fetched so every block is delivered in order. When a
+// delivered block is no longer canonical after a reorganization, it is sent
+// again with Reverted set before the blocks of the new canonical chain.
+// The channel is closed when ctx is canceled, the connection is closed or
+// the subscription fails.
+func (s *Substrate) SubscribeEvents(ctx context.Context, opts SubscribeOptions) (<-chan BlockEvents, error) {
+	ctx, done := s.track(ctx)
+
+	cl, _, err := s.getClient(ctx)
+	if err != nil {
+		done()
+		return nil, err
+	}
+
+	sub, err := subscribeHeads(cl, opts.Finalized)
+	if err != nil {
+		done()
+		return nil, err
+	}
+
+	ch := make(chan BlockEvents, opts.Buffer)
+	go func() {
+		defer done()
+		s.followHeads(ctx, cl, sub, ch)
+	}()
+
+	return ch, nil
+}
+
+func (s *Substrate) followHeads(ctx context.Context, cl Conn, sub headSubscription, ch chan<- BlockEvents) {
+	defer close(ch)
+	defer sub.Unsubscribe()
+
+	send := func(block BlockEvents) error {
+		select {
+		case ch <- block:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	tracker := newChainTracker(reorgDepth)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case err := <-sub.Err():
+			if err == nil {
+				err = errors.New("subscription closed")
+			}
+			_ = send(BlockEvents{Err: errors.Wrap(err, "chain heads subscription failed")})
+			return
+		case head, ok := <-sub.Chan():
+			if !ok {
+				_ = send(BlockEvents{Err: errors.New("chain heads subscription closed")})
+				return
+			}
+
+			if err := s.advance(cl, tracker, head, send); err != nil {
+				if ctx.Err() == nil {
+					_ = send(BlockEvents{Number: uint32(head.Number), Err: err})
+				}
+				return
+			}
+		}
+	}
+}
+
+// headerEvents gets the events and timestamp of a block, errors are set on
+// the returned value
+func (s *Substrate) headerEvents(cl Conn, header types.Header) BlockEvents {
+	block := BlockEvents{Number: uint32(header.Number), ParentHash: header.ParentHash}
+
+	var err error
+	block.Hash, err = headerHash(&header)
+	if err != nil {
+		block.Err = err
+		return block
+	}
+
+	meta, spec, err := s.metadataAt(cl, block.Hash)
+	if err != nil {
+		block.Err = err
+		return block
+	}
+
+	block.Timestamp, err = getTimeAt(cl, meta, &block.Hash)
+	if err != nil {
+		block.Err = errors.Wrapf(err, "failed to get time of block %d", block.Number)
+		return block
+	}
+
+	block.Events, err = getEventsAt(cl, meta, spec, block.Hash)
+	if err != nil {
+		block.Err = errors.Wrapf(err, "failed to get events of block %d", block.Number)
+	}
+
+	return block
+}
+
+// headerAt gets the header of the canonical block with the given number
+func headerAt(cl Conn, number uint32) (types.Header, error) {
+	hash, err := cl.RPC.Chain.GetBlockHash(uint64(number))
+	if err != nil {
+		return types.Header{}, errors.Wrapf(err, "failed to get block hash of block %d", number)
+	}
+
+	header, err := getHeader(cl, hash)
+	if err != nil {
+		return types.Header{}, errors.Wrapf(err, "failed to get header of block %d", number)
+	}
+
+	return *header, nil
+}
+
+// headerHash computes the hash of a block header
+func headerHash(header *types.Header) (types.Hash, error) {
+	data, err := types.Encode(header)
+	if err != nil {
+		return types.Hash{}, errors.Wrap(err, "failed to encode block header")
+	}
+
+	return blake2b.Sum256(data), nil
+}
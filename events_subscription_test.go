@@ -0,0 +1,149 @@
+package substrate
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/require"
+)
+
+func receiveBlock(t *testing.T, ch <-chan BlockEvents) BlockEvents {
+	select {
+	case block, ok := <-ch:
+		require.True(t, ok, "channel closed")
+		return block
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for block")
+	}
+
+	return BlockEvents{}
+}
+
+func TestSubscribeEvents(t *testing.T) {
+	node := newFakeNode(t)
+	chain := newFakeChain(t, node)
+
+	// Utility.ItemCompleted
+	itemCompleted := []byte{1 << 2, 0, 0, 0, 0, 0, 1, 2, 0}
+
+	first := chain.add(1, nil)
+	second := chain.add(2, itemCompleted)
+	third := chain.add(3, nil)
+
+	cl := testClient(t, node, DefaultManagerOptions())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch, err := cl.SubscribeEvents(ctx, SubscribeOptions{Finalized: true})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return node.subscribers("chain_subscribeFinalizedHeads") == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	var (
+		blocks    []uint32
+		completed int
+	)
+	var handlers EventHandlers
+	handlers.OnBlock(func(block BlockEvents) {
+		blocks = append(blocks, block.Number)
+	})
+	handlers.On("Utility_ItemCompleted", func(event interface{}) {
+		_, ok := event.(types.EventUtilityItemCompleted)
+		require.True(t, ok)
+		completed++
+	})
+
+	chain.head(first, true)
+	block := receiveBlock(t, ch)
+	require.NoError(t, block.Err)
+	require.Equal(t, uint32(1), block.Number)
+	hash, err := headerHash(&first)
+	require.NoError(t, err)
+	require.Equal(t, hash, block.Hash)
+	require.False(t, block.Timestamp.IsZero())
+	handlers.Handle(block)
+
+	// block 2 is skipped by the node but still delivered
+	chain.head(third, true)
+	for _, header := range []types.Header{second, third} {
+		block := receiveBlock(t, ch)
+		require.NoError(t, block.Err)
+		require.Equal(t, uint32(header.Number), block.Number)
+
+		hash, err := headerHash(&header)
+		require.NoError(t, err)
+		require.Equal(t, hash, block.Hash)
+		handlers.Handle(block)
+	}
+
+	require.Equal(t, []uint32{1, 2, 3}, blocks)
+	require.Equal(t, 1, completed)
+
+	cancel()
+	select {
+	case _, ok := <-ch:
+		require.False(t, ok)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "channel not closed after cancel")
+	}
+	require.Eventually(t, func() bool {
+		return node.count("chain_unsubscribeFinalizedHeads") == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestSubscribeEventsConnectionLost(t *testing.T) {
+	node := newFakeNode(t)
+	newFakeChain(t, node)
+
+	cl := testClient(t, node, DefaultManagerOptions())
+
+	ch, err := cl.SubscribeEvents(context.Background(), SubscribeOptions{})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return node.subscribers("chain_subscribeNewHead") == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	node.dropAll()
+
+	var handlers EventHandlers
+	errCh := make(chan error, 1)
+	go func() { errCh <- handlers.Run(ch) }()
+
+	select {
+	case err := <-errCh:
+		require.Error(t, err)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "subscription error not delivered")
+	}
+}
+
+func TestEventHandlersTyped(t *testing.T) {
+	var events EventRecords
+	events.SmartContractModule_ContractCreated = []ContractCreated{
+		{Contract: Contract{ContractID: 1}},
+		{Contract: Contract{ContractID: 2}},
+	}
+	events.TfgridModule_TwinStored = []TwinStored{{Twin: Twin{ID: 7}}}
+	events.Order = []EventRef{
+		{Name: "SmartContractModule_ContractCreated", Index: 0},
+		{Name: "TfgridModule_TwinStored", Index: 0},
+		{Name: "SmartContractModule_ContractCreated", Index: 1},
+	}
+
+	var seen []string
+	var handlers EventHandlers
+	handlers.OnContractCreated(func(event ContractCreated) {
+		seen = append(seen, fmt.Sprintf("contract %d", event.Contract.ContractID))
+	})
+	handlers.OnTwinStored(func(event TwinStored) {
+		seen = append(seen, fmt.Sprintf("twin %d", event.Twin.ID))
+	})
+
+	handlers.Handle(BlockEvents{Number: 1, Events: &events})
+	require.Equal(t, []string{"contract 1", "twin 7", "contract 2"}, seen)
+}
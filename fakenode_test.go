@@ -0,0 +1,546 @@
+package substrate
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeHandler answers a single json-rpc method call
+type fakeHandler func(params []json.RawMessage) (interface{}, error)
+
+type rpcMessage struct {
+	Version string            `json:"jsonrpc"`
+	ID      json.RawMessage   `json:"id,omitempty"`
+	Method  string            `json:"method,omitempty"`
+	Params  []json.RawMessage `json:"params,omitempty"`
+}
+
+type fakeConn struct {
+	ws *websocket.Conn
+	m  sync.Mutex
+}
+
+func (c *fakeConn) send(msg interface{}) error {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.ws.WriteJSON(msg)
+}
+
+type fakeSubscription struct {
+	conn *fakeConn
+	id   string
+}
+
+// fakeNode is a minimal substrate json-rpc server over websocket. It is
+// used to test the client without a running tfchain node.
+type fakeNode struct {
+	t   *testing.T
+	srv *httptest.Server
+
+	meta Meta
+
+	m        sync.Mutex
+	handlers map[string]fakeHandler
+	storage  map[string]string
+	now      time.Time
+	spec     types.U32
+	index    uint64
+	dialed   int
+	conns    map[*fakeConn]struct{}
+	calls    map[string]int
+	params   map[string][]json.RawMessage
+	subs     map[string][]*fakeSubscription
+	nextSub  int
+}
+
+func newFakeNode(t *testing.T) *fakeNode {
+	var meta types.Metadata
+	require.NoError(t, types.DecodeFromHex(types.MetadataV14Data, &meta))
+
+	n := &fakeNode{
+		t:        t,
+		meta:     &meta,
+		handlers: make(map[string]fakeHandler),
+		storage:  make(map[string]string),
+		now:      time.Now(),
+		spec:     1,
+		conns:    make(map[*fakeConn]struct{}),
+		calls:    make(map[string]int),
+		params:   make(map[string][]json.RawMessage),
+		subs:     make(map[string][]*fakeSubscription),
+	}
+
+	n.handle("state_getMetadata", func(params []json.RawMessage) (interface{}, error) {
+		return types.MetadataV14Data, nil
+	})
+	n.handle("state_getStorage", n.getStorage)
+	n.handle("chain_getBlockHash", func(params []json.RawMessage) (interface{}, error) {
+		return types.Hash{}.Hex(), nil
+	})
+	n.handle("chain_getBlock", func(params []json.RawMessage) (interface{}, error) {
+		return map[string]interface{}{
+			"block": map[string]interface{}{
+				"header": map[string]interface{}{
+					"parentHash":     types.Hash{}.Hex(),
+					"number":         "0x1",
+					"stateRoot":      types.Hash{}.Hex(),
+					"extrinsicsRoot": types.Hash{}.Hex(),
+					"digest":         map[string]interface{}{"logs": []string{}},
+				},
+				"extrinsics": []string{},
+			},
+		}, nil
+	})
+	n.handle("state_getRuntimeVersion", func(params []json.RawMessage) (interface{}, error) {
+		n.m.Lock()
+		defer n.m.Unlock()
+		return types.RuntimeVersion{SpecName: "fake", SpecVersion: n.spec}, nil
+	})
+	n.subscription("state_subscribeRuntimeVersion", "state_unsubscribeRuntimeVersion")
+	n.handle("system_accountNextIndex", func(params []json.RawMessage) (interface{}, error) {
+		n.m.Lock()
+		defer n.m.Unlock()
+		return n.index, nil
+	})
+
+	n.srv = httptest.NewServer(http.HandlerFunc(n.serve))
+	t.Cleanup(n.srv.Close)
+
+	return n
+}
+
+// URL of the fake node
+func (n *fakeNode) URL() string {
+	return "ws" + strings.TrimPrefix(n.srv.URL, "http")
+}
+
+// handle sets the handler for a method
+func (n *fakeNode) handle(method string, handler fakeHandler) {
+	n.m.Lock()
+	defer n.m.Unlock()
+	n.handlers[method] = handler
+}
+
+// subscription registers a subscribe method, all subscribers will receive
+// values sent with publish(method, ...)
+func (n *fakeNode) subscription(method, unsubscribe string) {
+	n.handle(unsubscribe, func(params []json.RawMessage) (interface{}, error) {
+		return true, nil
+	})
+	n.handle(method, func(params []json.RawMessage) (interface{}, error) {
+		// handled specially in serve since it needs the connection
+		return nil, nil
+	})
+	n.m.Lock()
+	n.subs[method] = nil
+	n.m.Unlock()
+}
+
+// publish sends a notification to all subscribers of a subscription method
+func (n *fakeNode) publish(method, notify string, value interface{}) {
+	n.m.Lock()
+	subs := append([]*fakeSubscription(nil), n.subs[method]...)
+	n.m.Unlock()
+
+	data, err := json.Marshal(value)
+	require.NoError(n.t, err)
+
+	for _, sub := range subs {
+		_ = sub.conn.send(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"method":  notify,
+			"params": map[string]interface{}{
+				"subscription": sub.id,
+				"result":       json.RawMessage(data),
+			},
+		})
+	}
+}
+
+// subscribers number of active subscriptions of a subscription method
+func (n *fakeNode) subscribers(method string) int {
+	n.m.Lock()
+	defer n.m.Unlock()
+	return len(n.subs[method])
+}
+
+// setTime sets the node Timestamp.Now value
+func (n *fakeNode) setTime(t time.Time) {
+	n.m.Lock()
+	defer n.m.Unlock()
+	n.now = t
+}
+
+// setSpec sets the runtime spec version and notifies
+// runtime version subscribers
+func (n *fakeNode) setSpec(spec types.U32) {
+	n.m.Lock()
+	n.spec = spec
+	n.m.Unlock()
+
+	n.publish(
+		"state_subscribeRuntimeVersion",
+		"state_runtimeVersion",
+		types.RuntimeVersion{SpecName: "fake", SpecVersion: spec},
+	)
+}
+
+// setNextIndex sets the value returned by system_accountNextIndex
+func (n *fakeNode) setNextIndex(index uint64) {
+	n.m.Lock()
+	defer n.m.Unlock()
+	n.index = index
+}
+
+// setStorage sets the raw value of a storage key
+func (n *fakeNode) setStorage(key types.StorageKey, value []byte) {
+	n.m.Lock()
+	defer n.m.Unlock()
+	n.storage[key.Hex()] = types.HexEncodeToString(value)
+}
+
+// dials number of accepted connections so far
+func (n *fakeNode) dials() int {
+	n.m.Lock()
+	defer n.m.Unlock()
+	return n.dialed
+}
+
+// open number of currently open connections
+func (n *fakeNode) open() int {
+	n.m.Lock()
+	defer n.m.Unlock()
+	return len(n.conns)
+}
+
+// count number of calls to a method so far
+func (n *fakeNode) count(method string) int {
+	n.m.Lock()
+	defer n.m.Unlock()
+	return n.calls[method]
+}
+
+// lastParams returns the params of the last call to a method
+func (n *fakeNode) lastParams(method string) []json.RawMessage {
+	n.m.Lock()
+	defer n.m.Unlock()
+	return n.params[method]
+}
+
+// dropAll closes all open connections from the server side
+func (n *fakeNode) dropAll() {
+	n.m.Lock()
+	defer n.m.Unlock()
+	for conn := range n.conns {
+		conn.ws.Close()
+	}
+}
+
+func (n *fakeNode) getStorage(params []json.RawMessage) (interface{}, error) {
+	var key string
+	if err := json.Unmarshal(params[0], &key); err != nil {
+		return nil, err
+	}
+
+	now, err := types.CreateStorageKey(n.meta, "Timestamp", "Now")
+	require.NoError(n.t, err)
+
+	n.m.Lock()
+	defer n.m.Unlock()
+	if key == now.Hex() {
+		data, err := types.Encode(types.U64(n.now.UnixNano() / int64(time.Millisecond)))
+		require.NoError(n.t, err)
+		return types.HexEncodeToString(data), nil
+	}
+
+	if value, ok := n.storage[key]; ok {
+		return value, nil
+	}
+
+	return nil, nil
+}
+
+func (n *fakeNode) serve(w http.ResponseWriter, r *http.Request) {
+	upgrader := websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }}
+	ws, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+
+	conn := &fakeConn{ws: ws}
+	n.m.Lock()
+	n.dialed++
+	n.conns[conn] = struct{}{}
+	n.m.Unlock()
+
+	defer func() {
+		n.m.Lock()
+		delete(n.conns, conn)
+		for method, subs := range n.subs {
+			var kept []*fakeSubscription
+			for _, sub := range subs {
+				if sub.conn != conn {
+					kept = append(kept, sub)
+				}
+			}
+			n.subs[method] = kept
+		}
+		n.m.Unlock()
+		ws.Close()
+	}()
+
+	for {
+		var msg rpcMessage
+		if err := ws.ReadJSON(&msg); err != nil {
+			return
+		}
+
+		n.m.Lock()
+		n.calls[msg.Method]++
+		n.params[msg.Method] = msg.Params
+		handler, ok := n.handlers[msg.Method]
+		_, isSub := n.subs[msg.Method]
+		n.m.Unlock()
+
+		response := map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      msg.ID,
+		}
+
+		if !ok {
+			response["error"] = map[string]interface{}{
+				"code":    -32601,
+				"message": fmt.Sprintf("method %s not found", msg.Method),
+			}
+		} else if isSub {
+			// the response must be sent before any notification
+			// of the new subscription, so the connection is locked
+			// until the response is out.
+			conn.m.Lock()
+			n.m.Lock()
+			n.nextSub++
+			sub := &fakeSubscription{conn: conn, id: fmt.Sprint(n.nextSub)}
+			n.subs[msg.Method] = append(n.subs[msg.Method], sub)
+			n.m.Unlock()
+			response["result"] = sub.id
+			err := conn.ws.WriteJSON(response)
+			conn.m.Unlock()
+			if err != nil {
+				return
+			}
+			continue
+		} else if result, err := handler(msg.Params); err != nil {
+			response["error"] = map[string]interface{}{
+				"code":    -32000,
+				"message": err.Error(),
+			}
+		} else {
+			response["result"] = result
+		}
+
+		if err := conn.send(response); err != nil {
+			return
+		}
+	}
+}
+
+// fakeChain serves a chain of blocks on a fake node, each block has its own
+// System.Events value. Blocks can be forked to script reorganizations
+type fakeChain struct {
+	node *fakeNode
+	// canonical chain by number
+	blocks map[uint32]types.Header
+	// all known blocks, including forks
+	headers map[types.Hash]types.Header
+	events  map[types.Hash][]byte
+	best    uint32
+}
+
+func newFakeChain(t *testing.T, node *fakeNode) *fakeChain {
+	c := &fakeChain{
+		node:    node,
+		blocks:  make(map[uint32]types.Header),
+		headers: make(map[types.Hash]types.Header),
+		events:  make(map[types.Hash][]byte),
+	}
+
+	key, err := types.CreateStorageKey(node.meta, "System", "Events", nil)
+	require.NoError(t, err)
+
+	node.subscription("chain_subscribeNewHead", "chain_unsubscribeNewHead")
+	node.subscription("chain_subscribeFinalizedHeads", "chain_unsubscribeFinalizedHeads")
+	node.handle("chain_getBlockHash", func(params []json.RawMessage) (interface{}, error) {
+		var number uint32
+		if err := json.Unmarshal(params[0], &number); err != nil {
+			return nil, err
+		}
+
+		node.m.Lock()
+		defer node.m.Unlock()
+		header, ok := c.blocks[number]
+		if !ok {
+			// genesis and unknown blocks
+			return types.Hash{}.Hex(), nil
+		}
+
+		hash, err := headerHash(&header)
+		return hash.Hex(), err
+	})
+	// latest header, or the header of a block hash
+	node.handle("chain_getHeader", func(params []json.RawMessage) (interface{}, error) {
+		node.m.Lock()
+		defer node.m.Unlock()
+		if len(params) == 0 {
+			return headerJSON(c.blocks[c.best]), nil
+		}
+
+		var block string
+		if err := json.Unmarshal(params[0], &block); err != nil {
+			return nil, err
+		}
+
+		hash, err := types.NewHashFromHexString(block)
+		if err != nil {
+			return nil, err
+		}
+
+		header, ok := c.headers[hash]
+		if !ok {
+			return nil, nil
+		}
+
+		return headerJSON(header), nil
+	})
+	node.handle("chain_getFinalizedHead", func(params []json.RawMessage) (interface{}, error) {
+		node.m.Lock()
+		defer node.m.Unlock()
+		header := c.blocks[c.best]
+		hash, err := headerHash(&header)
+		return hash.Hex(), err
+	})
+	node.handle("state_getStorage", func(params []json.RawMessage) (interface{}, error) {
+		var requested string
+		if err := json.Unmarshal(params[0], &requested); err != nil {
+			return nil, err
+		}
+
+		if requested != key.Hex() {
+			return node.getStorage(params)
+		}
+
+		var block string
+		if err := json.Unmarshal(params[1], &block); err != nil {
+			return nil, err
+		}
+
+		hash, err := types.NewHashFromHexString(block)
+		if err != nil {
+			return nil, err
+		}
+
+		node.m.Lock()
+		defer node.m.Unlock()
+		events, ok := c.events[hash]
+		if !ok {
+			// no events
+			events = []byte{0}
+		}
+
+		return types.HexEncodeToString(events), nil
+	})
+
+	return c
+}
+
+// add adds a block on top of the canonical block number-1 and returns its header
+func (c *fakeChain) add(number uint32, events []byte) types.Header {
+	c.node.m.Lock()
+	parent, ok := c.blocks[number-1]
+	c.node.m.Unlock()
+
+	if !ok {
+		return c.extend(types.NewHash([]byte{byte(number - 1)}), number, 0, events)
+	}
+
+	return c.fork(parent, 0, events)
+}
+
+// fork adds a block on top of parent and makes it the canonical head, blocks
+// of the previous chain above parent are not canonical anymore. The fork
+// value makes the block hash unique
+func (c *fakeChain) fork(parent types.Header, fork byte, events []byte) types.Header {
+	hash, err := headerHash(&parent)
+	require.NoError(c.node.t, err)
+
+	return c.extend(hash, uint32(parent.Number)+1, fork, events)
+}
+
+func (c *fakeChain) extend(parent types.Hash, number uint32, fork byte, events []byte) types.Header {
+	header := types.Header{
+		ParentHash: parent,
+		Number:     types.BlockNumber(number),
+		StateRoot:  types.NewHash([]byte{fork}),
+	}
+
+	hash, err := headerHash(&header)
+	require.NoError(c.node.t, err)
+
+	c.node.m.Lock()
+	defer c.node.m.Unlock()
+	for n := range c.blocks {
+		if n > number {
+			delete(c.blocks, n)
+		}
+	}
+	c.blocks[number] = header
+	c.headers[hash] = header
+	c.best = number
+	if events != nil {
+		c.events[hash] = events
+	}
+
+	return header
+}
+
+// head publishes a header to new heads (or finalized heads) subscribers
+func (c *fakeChain) head(header types.Header, finalized bool) {
+	method, notify := "chain_subscribeNewHead", "chain_newHead"
+	if finalized {
+		method, notify = "chain_subscribeFinalizedHeads", "chain_finalizedHead"
+	}
+
+	c.node.publish(method, notify, headerJSON(header))
+}
+
+func headerJSON(header types.Header) map[string]interface{} {
+	return map[string]interface{}{
+		"parentHash":     header.ParentHash.Hex(),
+		"number":         fmt.Sprintf("0x%x", uint32(header.Number)),
+		"stateRoot":      header.StateRoot.Hex(),
+		"extrinsicsRoot": header.ExtrinsicsRoot.Hex(),
+		"digest":         map[string]interface{}{"logs": []string{}},
+	}
+}
+
+// testClient returns a client of a manager with opts connected to node, both
+// are closed at the end of the test
+func testClient(t *testing.T, node *fakeNode, opts ManagerOptions) *Substrate {
+	mgr := NewManagerWithOptions(opts, node.URL())
+	t.Cleanup(func() { closeManager(t, mgr) })
+
+	sub, err := mgr.Substrate()
+	require.NoError(t, err)
+	t.Cleanup(sub.Close)
+
+	return sub
+}
@@ -1,6 +1,7 @@
 package substrate
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
@@ -159,7 +160,12 @@ type PublicIPInput struct {
 
 // GetFarm gets a farm with ID
 func (s *Substrate) GetFarm(id uint32) (*Farm, error) {
-	cl, meta, err := s.GetClient()
+	return s.GetFarmCtx(context.Background(), id)
+}
+
+// GetFarmCtx is like GetFarm but takes a context
+func (s *Substrate) GetFarmCtx(ctx context.Context, id uint32) (*Farm, error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -173,7 +179,7 @@ func (s *Substrate) GetFarm(id uint32) (*Farm, error) {
 		return nil, errors.Wrap(err, "failed to create substrate query key")
 	}
 
-	raw, err := cl.RPC.State.GetStorageRawLatest(key)
+	raw, err := s.getStorageRaw(cl, key)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to lookup entity")
 	}
@@ -182,7 +188,12 @@ func (s *Substrate) GetFarm(id uint32) (*Farm, error) {
 		return nil, errors.Wrap(ErrNotFound, "farm not found")
 	}
 
-	version, err := s.getVersion(*raw)
+	return s.decodeFarm(*raw)
+}
+
+// decodeFarm decodes a farm from its storage value
+func (s *Substrate) decodeFarm(raw types.StorageDataRaw) (*Farm, error) {
+	version, err := s.getVersion(raw)
 	if err != nil {
 		return nil, err
 	}
@@ -197,7 +208,7 @@ func (s *Substrate) GetFarm(id uint32) (*Farm, error) {
 	case 2:
 		fallthrough
 	case 1:
-		if err := types.Decode(*raw, &farm); err != nil {
+		if err := types.Decode(raw, &farm); err != nil {
 			return nil, errors.Wrap(err, "failed to load object")
 		}
 	default:
@@ -209,7 +220,12 @@ func (s *Substrate) GetFarm(id uint32) (*Farm, error) {
 
 // GetFarm gets a farm with ID
 func (s *Substrate) GetFarmByName(name string) (uint32, error) {
-	cl, meta, err := s.GetClient()
+	return s.GetFarmByNameCtx(context.Background(), name)
+}
+
+// GetFarmByNameCtx is like GetFarmByName but takes a context
+func (s *Substrate) GetFarmByNameCtx(ctx context.Context, name string) (uint32, error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -223,7 +239,7 @@ func (s *Substrate) GetFarmByName(name string) (uint32, error) {
 		return 0, errors.Wrap(err, "failed to create substrate query key")
 	}
 
-	raw, err := cl.RPC.State.GetStorageRawLatest(key)
+	raw, err := s.getStorageRaw(cl, key)
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to lookup entity")
 	}
@@ -243,13 +259,24 @@ func (s *Substrate) GetFarmByName(name string) (uint32, error) {
 // CreateFarm creates a farm
 // takes in a name and public ip list
 func (s *Substrate) CreateFarm(identity Identity, name string, publicIps []PublicIPInput) error {
-	cl, meta, err := s.GetClient()
-	if err != nil {
+	return s.CreateFarmCtx(context.Background(), identity, name, publicIps)
+}
+
+// CreateFarmCtx is like CreateFarm but takes a context
+func (s *Substrate) CreateFarmCtx(ctx context.Context, identity Identity, name string, publicIps []PublicIPInput) error {
+	if err := validateFarmName(name); err != nil {
 		return err
 	}
 
-	if name == "" {
-		return fmt.Errorf("name cannot be empty")
+	for _, ip := range publicIps {
+		if err := validatePublicIP(ip.IP, ip.Gateway); err != nil {
+			return err
+		}
+	}
+
+	cl, meta, err := s.getClient(ctx)
+	if err != nil {
+		return err
 	}
 
 	c, err := types.NewCall(meta, "TfgridModule.create_farm",
@@ -260,9 +287,184 @@ func (s *Substrate) CreateFarm(identity Identity, name string, publicIps []Publi
 		return errors.Wrap(err, "failed to create call")
 	}
 
-	if _, err := s.Call(cl, meta, identity, c); err != nil {
+	if _, err := s.CallCtx(ctx, cl, meta, identity, c); err != nil {
 		return errors.Wrap(err, "failed to create farm")
 	}
 
 	return nil
 }
+
+// UpdateFarm renames a farm
+func (s *Substrate) UpdateFarm(identity Identity, id uint32, name string) error {
+	return s.UpdateFarmCtx(context.Background(), identity, id, name)
+}
+
+// UpdateFarmCtx is like UpdateFarm but takes a context
+func (s *Substrate) UpdateFarmCtx(ctx context.Context, identity Identity, id uint32, name string) error {
+	if err := validateFarmName(name); err != nil {
+		return err
+	}
+
+	return s.farmCall(ctx, identity, "failed to update farm", "TfgridModule.update_farm", id, name)
+}
+
+// AddFarmIP adds a public ip to a farm, ip is in CIDR notation
+// and gw is the gateway of the ip network
+func (s *Substrate) AddFarmIP(identity Identity, id uint32, ip string, gw string) error {
+	return s.AddFarmIPCtx(context.Background(), identity, id, ip, gw)
+}
+
+// AddFarmIPCtx is like AddFarmIP but takes a context
+func (s *Substrate) AddFarmIPCtx(ctx context.Context, identity Identity, id uint32, ip string, gw string) error {
+	if err := validatePublicIP(ip, gw); err != nil {
+		return err
+	}
+
+	return s.farmCall(ctx, identity, "failed to add farm ip", "TfgridModule.add_farm_ip", id, ip, gw)
+}
+
+// RemoveFarmIP removes a public ip from a farm, the ip must not be
+// used by a contract
+func (s *Substrate) RemoveFarmIP(identity Identity, id uint32, ip string) error {
+	return s.RemoveFarmIPCtx(context.Background(), identity, id, ip)
+}
+
+// RemoveFarmIPCtx is like RemoveFarmIP but takes a context
+func (s *Substrate) RemoveFarmIPCtx(ctx context.Context, identity Identity, id uint32, ip string) error {
+	if _, _, err := parseIP(ip, false, publicIPErrors); err != nil {
+		return err
+	}
+
+	return s.farmCall(ctx, identity, "failed to remove farm ip", "TfgridModule.remove_farm_ip", id, ip)
+}
+
+// SetFarmPayoutAddress sets the stellar address farming rewards are paid to
+func (s *Substrate) SetFarmPayoutAddress(identity Identity, id uint32, address string) error {
+	return s.SetFarmPayoutAddressCtx(context.Background(), identity, id, address)
+}
+
+// SetFarmPayoutAddressCtx is like SetFarmPayoutAddress but takes a context
+func (s *Substrate) SetFarmPayoutAddressCtx(ctx context.Context, identity Identity, id uint32, address string) error {
+	if err := validateStellarAddress(address); err != nil {
+		return err
+	}
+
+	return s.farmCall(ctx, identity, "failed to set farm payout address", "TfgridModule.add_stellar_payout_v2address", id, address)
+}
+
+// GetFarmPayoutAddress gets the stellar payout address of a farm
+func (s *Substrate) GetFarmPayoutAddress(id uint32) (string, error) {
+	return s.GetFarmPayoutAddressCtx(context.Background(), id)
+}
+
+// GetFarmPayoutAddressCtx is like GetFarmPayoutAddress but takes a context
+func (s *Substrate) GetFarmPayoutAddressCtx(ctx context.Context, id uint32) (string, error) {
+	cl, meta, err := s.getClient(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	bytes, err := types.Encode(id)
+	if err != nil {
+		return "", errors.Wrap(err, "substrate: encoding error building query arguments")
+	}
+	key, err := types.CreateStorageKey(meta, "TfgridModule", "FarmPayoutV2AddressByFarmID", bytes, nil)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create substrate query key")
+	}
+
+	var address []byte
+	ok, err := s.getStorage(cl, key, &address)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to lookup entity")
+	}
+
+	if !ok || len(address) == 0 {
+		return "", errors.Wrap(ErrNotFound, "farm payout address not found")
+	}
+
+	return string(address), nil
+}
+
+// DeleteFarm deletes a farm, the farm must not have nodes or public ips
+func (s *Substrate) DeleteFarm(identity Identity, id uint32) error {
+	return s.DeleteFarmCtx(context.Background(), identity, id)
+}
+
+// DeleteFarmCtx is like DeleteFarm but takes a context
+func (s *Substrate) DeleteFarmCtx(ctx context.Context, identity Identity, id uint32) error {
+	return s.farmCall(ctx, identity, "failed to delete farm", "TfgridModule.delete_farm", id)
+}
+
+// AttachPolicyToFarm attaches a farming policy to a farm, with optional limits.
+// A zero OptionFarmingPolicyLimit removes the policy of the farm. Only allowed
+// for the council
+func (s *Substrate) AttachPolicyToFarm(identity Identity, id uint32, limits OptionFarmingPolicyLimit) error {
+	return s.AttachPolicyToFarmCtx(context.Background(), identity, id, limits)
+}
+
+// AttachPolicyToFarmCtx is like AttachPolicyToFarm but takes a context
+func (s *Substrate) AttachPolicyToFarmCtx(ctx context.Context, identity Identity, id uint32, limits OptionFarmingPolicyLimit) error {
+	return s.farmCall(ctx, identity, "failed to attach policy to farm", "TfgridModule.attach_policy_to_farm", id, limits)
+}
+
+// farmCall calls a farm extrinsic
+func (s *Substrate) farmCall(ctx context.Context, identity Identity, msg string, method string, args ...interface{}) error {
+	cl, meta, err := s.getClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	c, err := types.NewCall(meta, method, args...)
+	if err != nil {
+		return errors.Wrap(err, "failed to create call")
+	}
+
+	if _, err := s.CallCtx(ctx, cl, meta, identity, c); err != nil {
+		return errors.Wrap(err, msg)
+	}
+
+	return nil
+}
+
+// ScannedFarm is a farm found by ScanFarms
+type ScannedFarm struct {
+	ID   uint32
+	Farm Farm
+	Err  error
+}
+
+// ScanFarms is like ScanNodes but gets farms
+func (s *Substrate) ScanFarms(ctx context.Context, from, to uint32, opts ...RangeOption) (<-chan ScannedFarm, error) {
+	entries, err := s.scan(ctx, "TfgridModule", "Farms", uint64(from), uint64(to), encodeU32, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan ScannedFarm)
+	go func() {
+		defer close(ch)
+
+		for entry := range entries {
+			scanned := ScannedFarm{ID: uint32(entry.id), Err: entry.err}
+			if errors.Is(scanned.Err, ErrNotFound) {
+				scanned.Err = errors.Wrap(ErrNotFound, "farm not found")
+			} else if scanned.Err == nil {
+				farm, err := s.decodeFarm(entry.value)
+				if err != nil {
+					scanned.Err = err
+				} else {
+					scanned.Farm = *farm
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case ch <- scanned:
+			}
+		}
+	}()
+
+	return ch, nil
+}
@@ -1,6 +1,7 @@
 package substrate
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -20,3 +21,33 @@ func TestFarm(t *testing.T) {
 	require.Equal(t, testName, farm.Name)
 	require.Equal(t, twinID, uint32(farm.TwinID))
 }
+
+func TestCreateFarmValidation(t *testing.T) {
+	node := newFakeNode(t)
+	sub, identity := callTestClient(t, node)
+
+	err := sub.CreateFarm(identity, "my farm", nil)
+	require.ErrorIs(t, err, ErrInvalidFarmName)
+
+	err = sub.CreateFarm(identity, strings.Repeat("a", 41), nil)
+	require.ErrorIs(t, err, ErrFarmNameTooLong)
+
+	err = sub.CreateFarm(identity, "my_farm", []PublicIPInput{
+		{IP: "185.206.122.33/24", Gateway: "185.206.122.1"},
+		{IP: "10.10.10.10/24", Gateway: "10.10.10.1"},
+	})
+	require.ErrorIs(t, err, ErrInvalidPublicIP)
+
+	err = sub.CreateFarm(identity, "my_farm", []PublicIPInput{{IP: "185.206.122.33/24", Gateway: "185.206.123.1"}})
+	require.ErrorIs(t, err, ErrInvalidPublicIP)
+
+	require.ErrorIs(t, sub.AddFarmIP(identity, 1, "185.206.122.33", "185.206.122.1"), ErrInvalidPublicIP)
+	require.ErrorIs(t, sub.RemoveFarmIP(identity, 1, "1.1.1.1"), ErrPublicIPTooShort)
+	require.ErrorIs(t, sub.RemoveFarmIP(identity, 1, "185.206.122.133/24/"), ErrPublicIPTooLong)
+	require.ErrorIs(t, sub.RemoveFarmIP(identity, 1, "185.206.122.33"), ErrInvalidPublicIP)
+	require.ErrorIs(t, sub.SetFarmPayoutAddress(identity, 1, "GABC"), ErrInvalidStellarPublicKey)
+
+	// invalid inputs are never submitted
+	require.Equal(t, 0, node.count("author_submitAndWatchExtrinsic"))
+	require.Equal(t, 0, node.count("author_submitExtrinsic"))
+}
@@ -0,0 +1,130 @@
+//go:build ignore
+// +build ignore
+
+// gen_json generates the json.Marshaler and json.Unmarshaler implementations
+// of all exported structs of the package, delegating to EncodeJSON and
+// DecodeJSON. Structs that are not plain data, with unexported, func or chan
+// fields, and structs with their own MarshalJSON are skipped.
+//
+//	go run gen_json.go [output]
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"sort"
+	"strings"
+)
+
+const generated = "json_gen.go"
+
+func main() {
+	output := generated
+	if len(os.Args) > 1 {
+		output = os.Args[1]
+	}
+
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go") && fi.Name() != generated
+	}, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	pkg, ok := pkgs["substrate"]
+	if !ok {
+		log.Fatal("substrate package not found")
+	}
+
+	structs := make(map[string]*ast.StructType)
+	custom := make(map[string]bool)
+	for _, file := range pkg.Files {
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.GenDecl:
+				if decl.Tok != token.TYPE {
+					continue
+				}
+
+				for _, spec := range decl.Specs {
+					spec := spec.(*ast.TypeSpec)
+					if st, ok := spec.Type.(*ast.StructType); ok && spec.Name.IsExported() {
+						structs[spec.Name.Name] = st
+					}
+				}
+			case *ast.FuncDecl:
+				if decl.Recv != nil && decl.Name.Name == "MarshalJSON" {
+					custom[receiver(decl.Recv.List[0].Type)] = true
+				}
+			}
+		}
+	}
+
+	var names []string
+	for name, st := range structs {
+		if !custom[name] && isData(st) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by gen_json.go; DO NOT EDIT.\n\npackage substrate\n")
+	for _, name := range names {
+		fmt.Fprintf(&buf, `
+// MarshalJSON implementation
+func (v %[1]s) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *%[1]s) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+`, name)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(output, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// receiver returns the type name of a method receiver
+func receiver(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+
+	return ""
+}
+
+// isData checks the struct only has exported fields that are not funcs
+// or channels
+func isData(st *ast.StructType) bool {
+	for _, field := range st.Fields.List {
+		for _, name := range field.Names {
+			if !name.IsExported() {
+				return false
+			}
+		}
+
+		switch field.Type.(type) {
+		case *ast.FuncType, *ast.ChanType:
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,172 @@
+package substrate
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// maxCooldownShift caps the cool-down of an endpoint to 16 times
+	// the failure cool-down
+	maxCooldownShift = 4
+	// switchRatio is how much faster an endpoint must be than the current
+	// one before the manager moves to it
+	switchRatio = 2
+)
+
+// endpointHealth is the last known state of an endpoint
+type endpointHealth struct {
+	// probed is set once the endpoint was reached at least once
+	probed bool
+	// latency of the last health check
+	latency time.Duration
+	// lag is how far the head of the node was behind
+	// on the last health check
+	lag time.Duration
+	// failures is the number of consecutive failures
+	failures int
+	// until is the end of the cool-down, the endpoint is only used
+	// before that if all other endpoints are cooling down too
+	until time.Time
+}
+
+func (h *endpointHealth) cooling(now time.Time) bool {
+	return now.Before(h.until)
+}
+
+// healthTracker keeps the health of the manager endpoints, and
+// picks the endpoint to use. The picked endpoint is sticky, it's
+// only replaced if it fails or if a much faster endpoint is found
+type healthTracker struct {
+	cooldown time.Duration
+
+	m         sync.Mutex
+	endpoints map[string]*endpointHealth
+	current   string
+}
+
+func newHealthTracker(urls []string, cooldown time.Duration) *healthTracker {
+	endpoints := make(map[string]*endpointHealth, len(urls))
+	for _, url := range urls {
+		endpoints[url] = &endpointHealth{}
+	}
+
+	return &healthTracker{cooldown: cooldown, endpoints: endpoints}
+}
+
+// success records a successful health check of the endpoint
+func (h *healthTracker) success(endpoint string, latency, lag time.Duration) {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	health := h.get(endpoint)
+	health.probed = true
+	health.latency = latency
+	health.lag = lag
+	health.failures = 0
+	health.until = time.Time{}
+}
+
+// failure records a failed connection or health check of the endpoint, the
+// endpoint is put in cool-down, longer for every consecutive failure
+func (h *healthTracker) failure(endpoint string) {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	health := h.get(endpoint)
+	health.failures++
+
+	shift := health.failures - 1
+	if shift > maxCooldownShift {
+		shift = maxCooldownShift
+	}
+	health.until = time.Now().Add(h.cooldown << shift)
+
+	if h.current == endpoint {
+		h.current = ""
+	}
+}
+
+// best returns the endpoint to use. The current endpoint is kept as long as
+// it's healthy, otherwise the healthiest endpoint becomes the current one
+func (h *healthTracker) best(urls []string) string {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	now := time.Now()
+	if current, ok := h.endpoints[h.current]; ok && !current.cooling(now) && current.failures == 0 {
+		return h.current
+	}
+
+	h.current = h.order(urls, now)[0]
+	return h.current
+}
+
+// rebalance moves to the healthiest endpoint if it's
+// much faster than the current one
+func (h *healthTracker) rebalance(urls []string) {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	now := time.Now()
+	best := h.order(urls, now)[0]
+	current, ok := h.endpoints[h.current]
+	if !ok || !current.probed {
+		h.current = best
+		return
+	}
+
+	candidate := h.endpoints[best]
+	if candidate.probed && candidate.failures == 0 && candidate.latency*switchRatio < current.latency {
+		h.current = best
+	}
+}
+
+// ordered returns the endpoints from the healthiest to the least healthy
+func (h *healthTracker) ordered(urls []string) []string {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	return h.order(urls, time.Now())
+}
+
+// order sorts the endpoints by health: endpoints that are cooling down
+// come last, then endpoints with failures, endpoints that were never
+// reached, and finally by head lag (in blocks) and latency. Equally
+// healthy endpoints keep the order of urls.
+func (h *healthTracker) order(urls []string, now time.Time) []string {
+	ordered := append([]string(nil), urls...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		a, b := h.get(ordered[i]), h.get(ordered[j])
+
+		if a.cooling(now) != b.cooling(now) {
+			return !a.cooling(now)
+		}
+		if a.cooling(now) {
+			return a.until.Before(b.until)
+		}
+		if a.failures != b.failures {
+			return a.failures < b.failures
+		}
+		if a.probed != b.probed {
+			return a.probed
+		}
+		if a.lag/blockTime != b.lag/blockTime {
+			return a.lag < b.lag
+		}
+		return a.latency < b.latency
+	})
+
+	return ordered
+}
+
+func (h *healthTracker) get(endpoint string) *endpointHealth {
+	health, ok := h.endpoints[endpoint]
+	if !ok {
+		health = &endpointHealth{}
+		h.endpoints[endpoint] = health
+	}
+
+	return health
+}
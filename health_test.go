@@ -0,0 +1,125 @@
+package substrate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthTrackerOrder(t *testing.T) {
+	urls := []string{"a", "b", "c", "d"}
+	health := newHealthTracker(urls, time.Minute)
+
+	// nothing is known yet, urls order is kept
+	require.Equal(t, urls, health.ordered(urls))
+
+	health.success("b", 50*time.Millisecond, time.Second)
+	health.success("c", 10*time.Millisecond, time.Second)
+	health.success("d", 5*time.Millisecond, 8*time.Second)
+	health.failure("a")
+
+	require.Equal(t, []string{"c", "b", "d", "a"}, health.ordered(urls))
+
+	// the endpoint recovers after a success
+	health.success("a", time.Millisecond, 0)
+	require.Equal(t, []string{"a", "c", "b", "d"}, health.ordered(urls))
+}
+
+func TestHealthTrackerSticky(t *testing.T) {
+	urls := []string{"a", "b", "c"}
+	health := newHealthTracker(urls, time.Minute)
+
+	require.Equal(t, "a", health.best(urls))
+
+	// a faster endpoint doesn't replace a healthy current endpoint
+	health.success("a", 30*time.Millisecond, 0)
+	health.success("b", 20*time.Millisecond, 0)
+	require.Equal(t, "a", health.best(urls))
+
+	health.failure("a")
+	require.Equal(t, "b", health.best(urls))
+
+	// a recovered endpoint is not used until the current one fails
+	health.success("a", 30*time.Millisecond, 0)
+	require.Equal(t, "b", health.best(urls))
+
+	// unless it's much faster
+	health.success("c", 5*time.Millisecond, 0)
+	health.rebalance(urls)
+	require.Equal(t, "c", health.best(urls))
+}
+
+func TestHealthTrackerCooldown(t *testing.T) {
+	urls := []string{"a", "b"}
+	health := newHealthTracker(urls, time.Minute)
+
+	health.failure("a")
+	health.failure("a")
+	health.failure("b")
+
+	// all endpoints are cooling down, the one that
+	// recovers first is used
+	require.Equal(t, "b", health.best(urls))
+	require.WithinDuration(t, time.Now().Add(2*time.Minute), health.endpoints["a"].until, time.Second)
+
+	for i := 0; i < 10; i++ {
+		health.failure("a")
+	}
+	require.WithinDuration(t, time.Now().Add(16*time.Minute), health.endpoints["a"].until, time.Second)
+}
+
+func TestManagerFailover(t *testing.T) {
+	behind := newFakeNode(t)
+	behind.setTime(time.Now().Add(-time.Minute))
+	healthy := newFakeNode(t)
+
+	mgr := NewManager(behind.URL(), healthy.URL())
+	defer closeManager(t, mgr)
+
+	for i := 0; i < 5; i++ {
+		cl, err := mgr.Substrate()
+		require.NoError(t, err)
+		cl.Close()
+	}
+
+	// the lagging node is tried at most once, then it's cooling down
+	require.LessOrEqual(t, behind.dials(), 1)
+	require.Equal(t, 1, healthy.dials())
+
+	// with a larger acceptable delay the node is accepted
+	opts := DefaultManagerOptions()
+	opts.AcceptableDelay = 2 * time.Minute
+	relaxed := NewManagerWithOptions(opts, behind.URL())
+	defer closeManager(t, relaxed)
+
+	cl, err := relaxed.Substrate()
+	require.NoError(t, err)
+	cl.Close()
+}
+
+func TestManagerProbe(t *testing.T) {
+	first := newFakeNode(t)
+	second := newFakeNode(t)
+
+	opts := DefaultManagerOptions()
+	opts.ProbeInterval = 50 * time.Millisecond
+	mgr := NewManagerWithOptions(opts, first.URL(), second.URL())
+	defer closeManager(t, mgr)
+
+	// both endpoints are probed in the background
+	require.Eventually(t, func() bool {
+		return first.dials() > 0 && second.dials() > 0
+	}, 2*time.Second, 10*time.Millisecond)
+
+	// probes reuse the idle connections
+	time.Sleep(200 * time.Millisecond)
+	require.Equal(t, 1, first.dials())
+	require.Equal(t, 1, second.dials())
+
+	// and the probed connections are used by clients
+	cl, err := mgr.Substrate()
+	require.NoError(t, err)
+	cl.Close()
+	require.Equal(t, 2, first.dials()+second.dials())
+}
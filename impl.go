@@ -1,7 +1,9 @@
 package substrate
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"math/rand"
 	"sync"
 	"time"
@@ -14,10 +16,15 @@ import (
 )
 
 const (
-	// acceptable delay is amount of blocks (in second) that a node can
-	// be behind before we don't accept it. block time is 6 seconds, so
-	// right now we only allow 2 blocks delay
-	acceptableDelay = 2 * 6 * time.Second
+	// blockTime is the expected time between two blocks
+	blockTime = 6 * time.Second
+	// acceptable delay is the default amount of time that a node can
+	// be behind before we don't accept it. right now we only allow
+	// 2 blocks delay
+	acceptableDelay = 2 * blockTime
+	// failureCooldown is the default time an endpoint is not used
+	// after it fails
+	failureCooldown = 30 * time.Second
 )
 
 var (
@@ -42,18 +49,108 @@ type Manager interface {
 	Substrate() (*Substrate, error)
 }
 
-type mgrImpl struct {
-	urls []string
+// ManagerOptions configures the connections pool of a manager
+type ManagerOptions struct {
+	// MinIdle is the number of idle connections the manager tries to keep
+	// open per endpoint, even if they are idle for longer than IdleTimeout.
+	// It keeps a background routine running until the manager is closed
+	MinIdle int
+	// MaxIdle is the max number of idle connections kept per endpoint. A
+	// connection that is returned to a full pool is closed instead.
+	MaxIdle int
+	// IdleTimeout is how long a connection can stay idle in the pool before
+	// it's closed. Zero means idle connections are never evicted.
+	IdleTimeout time.Duration
+	// Metrics observes connections, rpc calls and extrinsics. Nil disables
+	// metrics
+	Metrics Metrics
+	// AcceptableDelay is how far the head of a node can be behind before
+	// its connections are rejected. Zero means the default of 2 blocks
+	AcceptableDelay time.Duration
+	// FailureCooldown is how long an endpoint is avoided after a failure,
+	// it doubles with every consecutive failure. Zero means the default
+	FailureCooldown time.Duration
+	// ProbeInterval is how often the health of all endpoints is checked
+	// in the background, every check dials all endpoints. Zero, the
+	// default, disables the background checks
+	ProbeInterval time.Duration
+}
+
+// DefaultManagerOptions returns the options used by NewManager
+func DefaultManagerOptions() ManagerOptions {
+	return ManagerOptions{
+		MinIdle:         0,
+		MaxIdle:         4,
+		IdleTimeout:     5 * time.Minute,
+		AcceptableDelay: acceptableDelay,
+		FailureCooldown: failureCooldown,
+	}
+}
+
+// pooledConn is a connection kept by the manager
+type pooledConn struct {
+	cl       Conn
+	meta     Meta
+	genesis  types.Hash
+	endpoint string
+	// since is when the connection was returned to the pool
+	since time.Time
+}
+
+func (c *pooledConn) close() {
+	c.cl.Client.Close()
+}
 
-	r int
-	m sync.Mutex
+type mgrImpl struct {
+	urls   []string
+	opts   ManagerOptions
+	cache  *metadataCache
+	nonces *nonceManager
+	health *healthTracker
+
+	// pm protects the pool state
+	pm     sync.Mutex
+	idle   map[string][]*pooledConn
+	closed bool
+	// cleaning is set while the janitor runs
+	cleaning bool
+
+	stop chan struct{}
 }
 
+var _ io.Closer = (*mgrImpl)(nil)
+
+// NewManager creates a new manager with the default options. The returned
+// manager implements io.Closer, closing it closes the idle connections and
+// stops its background routines. Idle connections are closed after
+// IdleTimeout anyway, so a manager that is never closed doesn't leak them
 func NewManager(url ...string) Manager {
+	return NewManagerWithOptions(DefaultManagerOptions(), url...)
+}
+
+// NewManagerWithOptions creates a new manager with custom pool options,
+// like NewManager the returned manager implements io.Closer
+func NewManagerWithOptions(opts ManagerOptions, url ...string) Manager {
 	if len(url) == 0 {
 		panic("at least one url is required")
 	}
 
+	if opts.MaxIdle < opts.MinIdle {
+		opts.MaxIdle = opts.MinIdle
+	}
+
+	if opts.Metrics == nil {
+		opts.Metrics = noopMetrics{}
+	}
+
+	if opts.AcceptableDelay <= 0 {
+		opts.AcceptableDelay = acceptableDelay
+	}
+
+	if opts.FailureCooldown <= 0 {
+		opts.FailureCooldown = failureCooldown
+	}
+
 	// the shuffle is needed so if one endpoints fails, and the next one
 	// is tried, we will end up moving all connections to the "next" endpoint
 	// which will get overloaded. Instead the shuffle helps to make the "next"
@@ -62,99 +159,438 @@ func NewManager(url ...string) Manager {
 		url[i], url[j] = url[j], url[i]
 	})
 
-	return &mgrImpl{
-		urls: url,
-		r:    rand.Intn(len(url)), // start with random url, then roundrobin
+	mgr := &mgrImpl{
+		urls:   url,
+		opts:   opts,
+		cache:  newMetadataCache(),
+		nonces: newNonceManager(),
+		health: newHealthTracker(url, opts.FailureCooldown),
+		idle:   make(map[string][]*pooledConn),
+		stop:   make(chan struct{}),
+	}
+
+	if opts.MinIdle > 0 {
+		// the pool must be filled before any connection is returned
+		mgr.cleaning = true
+		go mgr.janitor()
 	}
+
+	if opts.ProbeInterval > 0 {
+		go mgr.prober()
+	}
+
+	return mgr
 }
 
-// endpoint return the next endpoint to use
-// in roundrobin fashion. need to be called
-// while lock is acquired.
+// endpoint return the endpoint to use, the same endpoint is
+// used until it fails, then the healthiest endpoint is used
 func (p *mgrImpl) endpoint() string {
-	defer func() {
-		p.r = (p.r + 1) % len(p.urls)
-	}()
-
-	return p.urls[p.r]
+	return p.health.best(p.urls)
 }
 
-// Substrate return a new wrapped substrate connection
-// the connection must be closed after you are done using it
+// Substrate return a wrapped substrate connection from the pool, a new
+// connection is created if no healthy idle connection is available.
+// the connection must be closed after you are done using it, which returns
+// it to the pool.
 func (p *mgrImpl) Substrate() (*Substrate, error) {
-	cl, meta, err := p.Raw()
+	conn, err := p.get()
 	if err != nil {
 		return nil, err
 	}
 
-	return newSubstrate(cl, meta, p.put)
+	endpoint := conn.endpoint
+	return newSubstrate(conn.cl, conn.meta, p.cache, p.nonces, p.opts.Metrics, conn.genesis, func(s *Substrate) {
+		p.put(endpoint, s)
+	})
 }
 
 // Raw returns a RPC substrate client. plus meta. The returned connection
 // is not tracked by the pool, nor reusable. It's the caller responsibility
 // to close the connection when done
 func (p *mgrImpl) Raw() (Conn, Meta, error) {
-	// right now this pool implementation just tests the connection
-	// makes sure that it is still active, otherwise, tries again
-	// until the connection is restored.
-	// A better pool implementation can be done later were multiple connections
-	// can be handled
-	// TODO: thread safety!
-	p.m.Lock()
-	defer p.m.Unlock()
+	conn, err := p.dial()
+	if err != nil {
+		return nil, nil, err
+	}
 
+	return conn.cl, p.cache.current(conn.genesis, conn.meta), nil
+}
+
+// dial creates a new connection to the next healthy endpoint
+func (p *mgrImpl) dial() (*pooledConn, error) {
 	boff := backoff.WithMaxRetries(
 		backoff.NewConstantBackOff(200*time.Millisecond),
 		2*uint64(len(p.urls)),
 	)
 
-	var (
-		cl   *gsrpc.SubstrateAPI
-		meta *types.Metadata
-		err  error
-	)
-
-	err = backoff.RetryNotify(func() error {
+	var conn *pooledConn
+	err := backoff.RetryNotify(func() error {
 		endpoint := p.endpoint()
-		log.Debug().Str("url", endpoint).Msg("connecting")
-		cl, err = newSubstrateAPI(endpoint)
+		var err error
+		conn, err = p.connect(endpoint)
+		return err
+	}, boff, func(err error, _ time.Duration) {
+		log.Error().Err(err).Msg("failed to connect to endpoint, retrying")
+	})
+
+	return conn, err
+}
+
+// connect creates a new connection to the given endpoint
+func (p *mgrImpl) connect(endpoint string) (conn *pooledConn, err error) {
+	defer func() {
 		if err != nil {
-			return errors.Wrapf(err, "error connecting to substrate at '%s'", endpoint)
+			p.health.failure(endpoint)
 		}
+		p.opts.Metrics.ObserveConnect(endpoint, err)
+	}()
 
-		meta, err = cl.RPC.State.GetMetadataLatest()
-		if err != nil {
-			return errors.Wrapf(err, "error getting latest metadata at '%s'", endpoint)
+	log.Debug().Str("url", endpoint).Msg("connecting")
+	cl, err := newSubstrateAPI(endpoint, p.opts.Metrics)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error connecting to substrate at '%s'", endpoint)
+	}
+
+	genesis, err := cl.RPC.Chain.GetBlockHash(0)
+	if err != nil {
+		cl.Client.Close()
+		return nil, errors.Wrapf(err, "error getting genesis hash at '%s'", endpoint)
+	}
+
+	version, err := cl.RPC.State.GetRuntimeVersionLatest()
+	if err != nil {
+		cl.Client.Close()
+		return nil, errors.Wrapf(err, "error getting runtime version at '%s'", endpoint)
+	}
+
+	// metadata is only downloaded if it's not already
+	// cached for this chain and runtime version
+	meta, err := p.cache.get(cl, genesis, version.SpecVersion, nil)
+	if err != nil {
+		cl.Client.Close()
+		return nil, errors.Wrapf(err, "error getting latest metadata at '%s'", endpoint)
+	}
+
+	types.SetSerDeOptions(types.SerDeOptionsFromMetadata(meta))
+
+	conn = &pooledConn{cl: cl, meta: meta, genesis: genesis, endpoint: endpoint}
+	if err := p.validate(conn); err != nil {
+		conn.close()
+		return nil, err
+	}
+
+	// keep the cached metadata up to date on runtime upgrades. this
+	// stops once the connection is closed.
+	follow, err := p.cache.watch(cl, genesis)
+	if err != nil {
+		conn.close()
+		return nil, err
+	}
+
+	go follow()
+
+	return conn, nil
+}
+
+// validate makes sure the connection is alive, and that the node it's
+// connected to is not behind. The health of the endpoint is updated on
+// success, callers record failures.
+func (p *mgrImpl) validate(conn *pooledConn) error {
+	start := time.Now()
+	t, err := getTime(conn.cl, p.cache.current(conn.genesis, conn.meta))
+	if err != nil {
+		return errors.Wrapf(err, "error getting node time at '%s'", conn.endpoint)
+	}
+
+	lag := time.Since(t)
+	if lag > p.opts.AcceptableDelay {
+		return fmt.Errorf("node '%s' is behind acceptable delay with timestamp '%s'", conn.endpoint, t)
+	}
+
+	p.health.success(conn.endpoint, time.Since(start), lag)
+	return nil
+}
+
+// get checks out a healthy idle connection from the pool, or dials
+// a new one if none is available
+func (p *mgrImpl) get() (*pooledConn, error) {
+	for {
+		conn := p.pop()
+		if conn == nil {
+			break
 		}
 
-		t, err := getTime(cl, meta)
-		if err != nil {
-			return errors.Wrapf(err, "error getting node time at '%s'", endpoint)
+		if err := p.validate(conn); err != nil {
+			log.Debug().Err(err).Str("url", conn.endpoint).Msg("dropping unhealthy idle connection")
+			p.health.failure(conn.endpoint)
+			conn.close()
+			continue
 		}
 
-		if time.Since(t) > acceptableDelay {
-			return fmt.Errorf("node '%s' is behind acceptable delay with timestamp '%s'", endpoint, t)
+		return conn, nil
+	}
+
+	return p.dial()
+}
+
+// pop removes the most recently used idle connection from the pool
+// starting with the current endpoint, then from the healthiest to the
+// least healthy endpoint
+func (p *mgrImpl) pop() *pooledConn {
+	endpoints := append([]string{p.endpoint()}, p.health.ordered(p.urls)...)
+
+	p.pm.Lock()
+	defer p.pm.Unlock()
+
+	for _, endpoint := range endpoints {
+		if conn := p.popEndpoint(endpoint); conn != nil {
+			return conn
 		}
+	}
 
-		return nil
+	return nil
+}
 
-	}, boff, func(err error, _ time.Duration) {
-		log.Error().Err(err).Msg("failed to connect to endpoint, retrying")
-	})
+// popEndpoint removes the most recently used idle connection of
+// the endpoint, pm must be held
+func (p *mgrImpl) popEndpoint(endpoint string) *pooledConn {
+	conns := p.idle[endpoint]
+	if len(conns) == 0 {
+		return nil
+	}
 
-	return cl, meta, err
+	conn := conns[len(conns)-1]
+	p.idle[endpoint] = conns[:len(conns)-1]
+	return conn
 }
 
-// TODO: implement reusable connections instead of
-// closing the connection.
-func (p *mgrImpl) put(cl *Substrate) {
-	// naive put implementation for now
-	// we just immediately kill the connection
-	if cl.cl != nil {
-		cl.cl.Client.Close()
+// put returns the connection of the substrate client to the pool
+func (p *mgrImpl) put(endpoint string, cl *Substrate) {
+	if cl.cl == nil {
+		// already closed
+		return
 	}
+
+	conn := &pooledConn{
+		cl:       cl.cl,
+		meta:     cl.meta,
+		genesis:  cl.genesis,
+		endpoint: endpoint,
+		since:    time.Now(),
+	}
+
 	cl.cl = nil
 	cl.meta = nil
+
+	p.release(conn)
+}
+
+// release adds an idle connection to the pool, or closes it
+// if the pool is full or closed
+func (p *mgrImpl) release(conn *pooledConn) {
+	p.pm.Lock()
+	if p.closed || len(p.idle[conn.endpoint]) >= p.opts.MaxIdle {
+		p.pm.Unlock()
+		conn.close()
+		return
+	}
+
+	p.idle[conn.endpoint] = append(p.idle[conn.endpoint], conn)
+	p.clean()
+	p.pm.Unlock()
+}
+
+// clean starts the janitor if it's not running and idle connections
+// can expire, pm must be held
+func (p *mgrImpl) clean() {
+	if p.cleaning || p.opts.IdleTimeout <= 0 {
+		return
+	}
+
+	p.cleaning = true
+	go p.janitor()
+}
+
+// Close closes all idle connections held by the manager and stops its
+// background routines. Connections that are still in use are closed when
+// they are returned to the manager.
+func (p *mgrImpl) Close() error {
+	p.pm.Lock()
+	defer p.pm.Unlock()
+
+	if p.closed {
+		return nil
+	}
+
+	p.closed = true
+	close(p.stop)
+
+	for endpoint, conns := range p.idle {
+		for _, conn := range conns {
+			conn.close()
+		}
+		delete(p.idle, endpoint)
+	}
+
+	return nil
+}
+
+// janitor evicts connections that has been idle for too long and keeps
+// the pool filled with at least MinIdle connections per endpoint. It
+// stops once the pool is empty, and is started again by the next
+// connection returned to the pool
+func (p *mgrImpl) janitor() {
+	interval := p.opts.IdleTimeout / 2
+	if interval <= 0 || interval > time.Minute {
+		interval = time.Minute
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+		}
+
+		p.evict()
+		p.fill()
+
+		if p.drained() {
+			return
+		}
+	}
+}
+
+// drained checks if the pool has no idle connections left and none has
+// to be kept open, then marks the janitor as stopped
+func (p *mgrImpl) drained() bool {
+	p.pm.Lock()
+	defer p.pm.Unlock()
+
+	if p.opts.MinIdle > 0 {
+		return false
+	}
+
+	for _, conns := range p.idle {
+		if len(conns) > 0 {
+			return false
+		}
+	}
+
+	p.cleaning = false
+	return true
+}
+
+// evict closes connections that are idle for more than IdleTimeout
+// while keeping at least MinIdle connections per endpoint
+func (p *mgrImpl) evict() {
+	if p.opts.IdleTimeout <= 0 {
+		return
+	}
+
+	var evicted []*pooledConn
+
+	p.pm.Lock()
+	for endpoint, conns := range p.idle {
+		// conns are sorted from oldest to newest
+		for len(conns) > p.opts.MinIdle && time.Since(conns[0].since) > p.opts.IdleTimeout {
+			evicted = append(evicted, conns[0])
+			conns = conns[1:]
+		}
+		p.idle[endpoint] = conns
+	}
+	p.pm.Unlock()
+
+	for _, conn := range evicted {
+		log.Debug().Str("url", conn.endpoint).Msg("closing idle connection")
+		conn.close()
+	}
+}
+
+// fill opens new connections to endpoints that has less than MinIdle
+// idle connections
+func (p *mgrImpl) fill() {
+	for _, endpoint := range p.urls {
+		p.pm.Lock()
+		missing := p.opts.MinIdle - len(p.idle[endpoint])
+		p.pm.Unlock()
+
+		for ; missing > 0; missing-- {
+			conn, err := p.connect(endpoint)
+			if err != nil {
+				log.Debug().Err(err).Str("url", endpoint).Msg("failed to fill connections pool")
+				break
+			}
+
+			conn.since = time.Now()
+			p.pm.Lock()
+			if p.closed {
+				p.pm.Unlock()
+				conn.close()
+				return
+			}
+			p.idle[endpoint] = append(p.idle[endpoint], conn)
+			p.pm.Unlock()
+		}
+	}
+}
+
+// prober checks the health of all endpoints every ProbeInterval
+func (p *mgrImpl) prober() {
+	ticker := time.NewTicker(p.opts.ProbeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+		}
+
+		p.probe()
+	}
+}
+
+// probe checks the health of all endpoints, then moves to the
+// healthiest endpoint if it's much faster than the current one
+func (p *mgrImpl) probe() {
+	for _, endpoint := range p.urls {
+		p.probeEndpoint(endpoint)
+	}
+
+	p.health.rebalance(p.urls)
+}
+
+// probeEndpoint checks the health of an endpoint with one of its idle
+// connections, or with a new connection that is then kept in the pool
+func (p *mgrImpl) probeEndpoint(endpoint string) {
+	p.pm.Lock()
+	conn := p.popEndpoint(endpoint)
+	p.pm.Unlock()
+
+	if conn != nil {
+		if err := p.validate(conn); err != nil {
+			log.Debug().Err(err).Str("url", endpoint).Msg("dropping unhealthy idle connection")
+			p.health.failure(endpoint)
+			conn.close()
+			return
+		}
+
+		p.release(conn)
+		return
+	}
+
+	conn, err := p.connect(endpoint)
+	if err != nil {
+		log.Debug().Err(err).Str("url", endpoint).Msg("endpoint health check failed")
+		return
+	}
+
+	conn.since = time.Now()
+	p.release(conn)
 }
 
 // Substrate client
@@ -162,20 +598,66 @@ type Substrate struct {
 	cl   Conn
 	meta Meta
 
+	// cache is the metadata cache shared with the manager
+	// nonces tracks nonces of in flight extrinsics, also shared with the manager
+	// genesis is the genesis hash of the connected chain
+	cache   *metadataCache
+	nonces  *nonceManager
+	genesis types.Hash
+	metrics Metrics
+	// at is the block the client is pinned to, nil for the latest block
+	at *types.Hash
+
+	// routines are the background routines using the connection, like
+	// subscriptions. They are stopped when the connection is closed
+	routines sync.WaitGroup
+	stop     chan struct{}
+	stopOnce sync.Once
+
 	close func(s *Substrate)
 }
 
 // NewSubstrate creates a substrate client
-func newSubstrate(cl Conn, meta Meta, close func(*Substrate)) (*Substrate, error) {
-	return &Substrate{cl: cl, meta: meta, close: close}, nil
+func newSubstrate(cl Conn, meta Meta, cache *metadataCache, nonces *nonceManager, metrics Metrics, genesis types.Hash, close func(*Substrate)) (*Substrate, error) {
+	return &Substrate{cl: cl, meta: meta, cache: cache, nonces: nonces, metrics: metrics, genesis: genesis, stop: make(chan struct{}), close: close}, nil
 }
 
 func (s *Substrate) Close() {
+	s.stopOnce.Do(func() { close(s.stop) })
+	s.routines.Wait()
 	s.close(s)
 }
 
+// track registers a background routine using the connection. The returned
+// context is canceled when the connection is closed, and Close waits for
+// done to be called before releasing the connection
+func (s *Substrate) track(ctx context.Context) (tracked context.Context, done func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	s.routines.Add(1)
+
+	go func() {
+		select {
+		case <-s.stop:
+		case <-ctx.Done():
+		}
+		cancel()
+	}()
+
+	return ctx, func() {
+		cancel()
+		s.routines.Done()
+	}
+}
+
+// GetClient returns the underlying connection and the metadata of
+// the latest runtime version of the chain, or the metadata at the
+// pinned block if the client is pinned
 func (s *Substrate) GetClient() (Conn, Meta, error) {
-	return s.cl, s.meta, nil
+	if s.at != nil {
+		return s.cl, s.meta, nil
+	}
+
+	return s.cl, s.cache.current(s.genesis, s.meta), nil
 }
 
 func (s *Substrate) getVersion(b types.StorageDataRaw) (uint32, error) {
@@ -188,29 +670,56 @@ func (s *Substrate) getVersion(b types.StorageDataRaw) (uint32, error) {
 }
 
 func (s *Substrate) Time() (t time.Time, err error) {
-	cl, meta, err := s.GetClient()
+	return s.TimeCtx(context.Background())
+}
+
+// TimeCtx is like Time but takes a context
+func (s *Substrate) TimeCtx(ctx context.Context) (t time.Time, err error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return t, err
 	}
 
-	return getTime(cl, meta)
+	return getTimeAt(cl, meta, s.at)
 }
 
 func getTime(cl Conn, meta Meta) (t time.Time, err error) {
+	return getTimeAt(cl, meta, nil)
+}
+
+// getTimeAt gets the chain time at block, or at the latest block if block is nil
+func getTimeAt(cl Conn, meta Meta, block *types.Hash) (t time.Time, err error) {
 	key, err := types.CreateStorageKey(meta, "Timestamp", "Now", nil)
 	if err != nil {
 		return t, errors.Wrap(err, "failed to create substrate query key")
 	}
 
-	raw, err := cl.RPC.State.GetStorageRawLatest(key)
+	var raw *types.StorageDataRaw
+	if block == nil {
+		raw, err = cl.RPC.State.GetStorageRawLatest(key)
+	} else {
+		raw, err = cl.RPC.State.GetStorageRaw(key, *block)
+	}
 	if err != nil {
 		return t, errors.Wrap(err, "failed to lookup entity")
 	}
 
-	var stamp types.Moment
-	if err := types.Decode(*raw, &stamp); err != nil {
+	t, err = decodeTimestamp(*raw)
+	if err != nil {
 		return t, errors.Wrap(err, "failed to get node time")
 	}
 
-	return stamp.Time, nil
+	return t, nil
+}
+
+// decodeTimestamp decodes a Timestamp.Now value. It's decoded as
+// milliseconds instead of types.Moment which gets the sub second
+// part wrong
+func decodeTimestamp(data []byte) (time.Time, error) {
+	var stamp types.U64
+	if err := types.Decode(data, &stamp); err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Unix(0, int64(stamp)*int64(time.Millisecond)), nil
 }
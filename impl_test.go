@@ -0,0 +1,191 @@
+package substrate
+
+import (
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// closeManager closes a manager created by NewManager
+func closeManager(t *testing.T, mgr Manager) {
+	closer, ok := mgr.(io.Closer)
+	require.True(t, ok, "manager is not closable")
+	require.NoError(t, closer.Close())
+}
+
+func TestManagerReusesConnections(t *testing.T) {
+	node := newFakeNode(t)
+
+	mgr := NewManager(node.URL())
+	defer closeManager(t, mgr)
+
+	for i := 0; i < 5; i++ {
+		cl, err := mgr.Substrate()
+		require.NoError(t, err)
+
+		_, err = cl.Time()
+		require.NoError(t, err)
+		cl.Close()
+	}
+
+	require.Equal(t, 1, node.dials())
+	require.Equal(t, 1, node.open())
+}
+
+func TestManagerMaxIdle(t *testing.T) {
+	node := newFakeNode(t)
+
+	opts := DefaultManagerOptions()
+	opts.MaxIdle = 1
+	mgr := NewManagerWithOptions(opts, node.URL())
+	defer closeManager(t, mgr)
+
+	var clients []*Substrate
+	for i := 0; i < 3; i++ {
+		cl, err := mgr.Substrate()
+		require.NoError(t, err)
+		clients = append(clients, cl)
+	}
+
+	require.Equal(t, 3, node.dials())
+
+	for _, cl := range clients {
+		cl.Close()
+		// closing twice must not return the connection twice
+		cl.Close()
+	}
+
+	require.Eventually(t, func() bool {
+		return node.open() == 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestManagerConcurrent(t *testing.T) {
+	node := newFakeNode(t)
+
+	mgr := NewManager(node.URL())
+	defer closeManager(t, mgr)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 5; j++ {
+				cl, err := mgr.Substrate()
+				if !assertNoError(t, err) {
+					return
+				}
+				_, err = cl.Time()
+				assertNoError(t, err)
+				cl.Close()
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.LessOrEqual(t, node.dials(), 10)
+}
+
+func TestManagerDropsUnhealthyConnections(t *testing.T) {
+	node := newFakeNode(t)
+
+	mgr := NewManager(node.URL())
+	defer closeManager(t, mgr)
+
+	cl, err := mgr.Substrate()
+	require.NoError(t, err)
+	cl.Close()
+
+	// node falls behind, the idle connection is not valid anymore
+	// and dialing a new one also fails.
+	node.setTime(time.Now().Add(-time.Hour))
+	_, err = mgr.Substrate()
+	require.Error(t, err)
+
+	node.setTime(time.Now())
+	cl, err = mgr.Substrate()
+	require.NoError(t, err)
+	cl.Close()
+
+	require.Eventually(t, func() bool {
+		return node.open() == 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestManagerEvictsIdleConnections(t *testing.T) {
+	node := newFakeNode(t)
+
+	opts := ManagerOptions{
+		MinIdle:     1,
+		MaxIdle:     4,
+		IdleTimeout: 100 * time.Millisecond,
+	}
+
+	mgr := NewManagerWithOptions(opts, node.URL())
+	defer closeManager(t, mgr)
+
+	var clients []*Substrate
+	for i := 0; i < 3; i++ {
+		cl, err := mgr.Substrate()
+		require.NoError(t, err)
+		clients = append(clients, cl)
+	}
+
+	for _, cl := range clients {
+		cl.Close()
+	}
+
+	require.Equal(t, 3, node.open())
+	require.Eventually(t, func() bool {
+		return node.open() == 1
+	}, 2*time.Second, 10*time.Millisecond)
+
+	closeManager(t, mgr)
+	require.Eventually(t, func() bool {
+		return node.open() == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestManagerJanitorStopsWhenDrained(t *testing.T) {
+	node := newFakeNode(t)
+
+	opts := DefaultManagerOptions()
+	opts.IdleTimeout = 100 * time.Millisecond
+	mgr := NewManagerWithOptions(opts, node.URL()).(*mgrImpl)
+
+	cleaning := func() bool {
+		mgr.pm.Lock()
+		defer mgr.pm.Unlock()
+		return mgr.cleaning
+	}
+
+	// nothing runs until a connection is returned to the pool
+	require.False(t, cleaning())
+
+	for i := 0; i < 2; i++ {
+		cl, err := mgr.Substrate()
+		require.NoError(t, err)
+		cl.Close()
+		require.True(t, cleaning())
+
+		// the manager is never closed, the idle connection is evicted
+		// and the janitor stops
+		require.Eventually(t, func() bool {
+			return node.open() == 0 && !cleaning()
+		}, 2*time.Second, 10*time.Millisecond)
+	}
+}
+
+// assertNoError is like require.NoError but safe to use
+// from other go routines than the test routine.
+func assertNoError(t *testing.T, err error) bool {
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+		return false
+	}
+	return true
+}
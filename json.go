@@ -0,0 +1,907 @@
+package substrate
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/pkg/errors"
+)
+
+// the MarshalJSON and UnmarshalJSON methods of the package structs are
+// generated in json_gen.go
+//go:generate go run gen_json.go
+
+// EncodeJSON encodes any type of the package to JSON, including the
+// go-substrate-rpc-client types it uses, with the following rules:
+//   - structs are objects keyed by field name (or json tag) in field order,
+//     embedded structs are flattened
+//   - enums (structs of IsX flags) are the name of the variant, like
+//     "Created", or an object of the variant name to its value, like
+//     {"GracePeriod": 1200}. An enum without variant is null
+//   - options are null or their value
+//   - 128 and 256 bits integers and compact values are decimal strings
+//   - accounts are SS58 addresses
+//   - bytes and byte arrays are 0x prefixed hex strings, except HexHash
+//     which is a string
+//   - EventRecords is a list of FilteredEvent, in the order of the events
+//   - errors are their message and identities their address, keys are
+//     never encoded
+func EncodeJSON(v interface{}) ([]byte, error) {
+	value, err := encodeValue(reflect.ValueOf(v))
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(value)
+}
+
+// DecodeJSON decodes JSON encoded with EncodeJSON into v, a pointer.
+// Identities are not decoded, and values of interface{} fields are decoded
+// as generic JSON values, except for events which are decoded to the type of
+// their EventRecords field
+func DecodeJSON(data []byte, v interface{}) error {
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Ptr || value.IsNil() {
+		return fmt.Errorf("expected a non nil pointer, got %T", v)
+	}
+
+	return decodeValue(data, value.Elem())
+}
+
+// WriteNDJSON writes the events as newline delimited JSON, a FilteredEvent
+// per line
+func (e *EventRecords) WriteNDJSON(w io.Writer) error {
+	return writeNDJSON(w, (&EventFilter{}).Events(e))
+}
+
+// WriteNDJSON writes the events of the block as newline delimited JSON, a
+// FilteredEvent per line
+func (b BlockEvents) WriteNDJSON(w io.Writer) error {
+	return writeNDJSON(w, (&EventFilter{}).Filter(b))
+}
+
+// ReadNDJSON reads events written with WriteNDJSON
+func ReadNDJSON(r io.Reader) ([]FilteredEvent, error) {
+	var events []FilteredEvent
+
+	decoder := json.NewDecoder(r)
+	for {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err == io.EOF {
+			return events, nil
+		} else if err != nil {
+			return nil, errors.Wrapf(err, "failed to read event #%d", len(events))
+		}
+
+		var event FilteredEvent
+		if err := decodeFilteredEvent(raw, &event); err != nil {
+			return nil, errors.Wrapf(err, "failed to decode event #%d", len(events))
+		}
+
+		events = append(events, event)
+	}
+}
+
+func writeNDJSON(w io.Writer, events []FilteredEvent) error {
+	buf := bufio.NewWriter(w)
+	for _, event := range events {
+		data, err := EncodeJSON(event)
+		if err != nil {
+			return errors.Wrapf(err, "failed to encode event %s", event.Name)
+		}
+
+		if _, err := buf.Write(append(data, '\n')); err != nil {
+			return err
+		}
+	}
+
+	return buf.Flush()
+}
+
+// MarshalJSON implementation
+func (h HexHash) MarshalJSON() ([]byte, error) { return EncodeJSON(h) }
+
+// UnmarshalJSON implementation
+func (h *HexHash) UnmarshalJSON(data []byte) error { return DecodeJSON(data, h) }
+
+// MarshalJSON implementation
+func (f Finality) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.String())
+}
+
+// UnmarshalJSON implementation
+func (f *Finality) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+
+	for _, finality := range []Finality{WaitInBlock, WaitFinalized, FireAndForget} {
+		if finality.String() == name {
+			*f = finality
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown finality %q", name)
+}
+
+var (
+	packagePath = reflect.TypeOf(EventRecords{}).PkgPath()
+
+	eventRecordsType  = reflect.TypeOf(EventRecords{})
+	filteredEventType = reflect.TypeOf(FilteredEvent{})
+	accountType       = reflect.TypeOf(AccountID{})
+	rawAccountType    = reflect.TypeOf(types.AccountID{})
+	hexHashType       = reflect.TypeOf(HexHash{})
+	u128Type          = reflect.TypeOf(types.U128{})
+	u256Type          = reflect.TypeOf(types.U256{})
+	i128Type          = reflect.TypeOf(types.I128{})
+	i256Type          = reflect.TypeOf(types.I256{})
+	compactType       = reflect.TypeOf(types.UCompact{})
+	bigIntType        = reflect.TypeOf(&big.Int{})
+	keyringPairType   = reflect.TypeOf(signature.KeyringPair{})
+	identityType      = reflect.TypeOf((*Identity)(nil)).Elem()
+	errorType         = reflect.TypeOf((*error)(nil)).Elem()
+	marshalerType     = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	unmarshalerType   = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// jsonObject is a JSON object that keeps the order of its fields
+type jsonObject []jsonField
+
+type jsonField struct {
+	key   string
+	value interface{}
+}
+
+func (o jsonObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, field := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		key, err := json.Marshal(field.key)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := json.Marshal(field.value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to encode %s", field.key)
+		}
+
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// enumVariant is a variant of an enum struct, the index of its IsX flag and
+// of the fields holding its value
+type enumVariant struct {
+	name  string
+	flag  int
+	value []int
+}
+
+// enumVariants returns the variants of an enum struct, whose fields are IsX
+// flags each followed by the fields of the variant value
+func enumVariants(t reflect.Type) ([]enumVariant, bool) {
+	if t.Kind() != reflect.Struct || t.NumField() == 0 || !isFlag(t.Field(0)) {
+		return nil, false
+	}
+
+	var variants []enumVariant
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if isFlag(field) {
+			variants = append(variants, enumVariant{name: strings.TrimPrefix(field.Name, "Is"), flag: i})
+			continue
+		}
+
+		if field.PkgPath != "" {
+			continue
+		}
+
+		last := &variants[len(variants)-1]
+		last.value = append(last.value, i)
+	}
+
+	return variants, true
+}
+
+func isFlag(field reflect.StructField) bool {
+	return field.Type.Kind() == reflect.Bool && strings.HasPrefix(field.Name, "Is") && len(field.Name) > 2
+}
+
+// isOption checks for option structs of the package, with HasValue and
+// AsValue fields
+func isOption(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t.NumField() == 2 &&
+		t.Field(0).Name == "HasValue" && t.Field(0).Type.Kind() == reflect.Bool &&
+		t.Field(1).Name == "AsValue"
+}
+
+// isRawOption checks for go-substrate-rpc-client options, with Unwrap and
+// SetSome methods
+func isRawOption(t reflect.Type) bool {
+	unwrap, ok := t.MethodByName("Unwrap")
+	if !ok || unwrap.Type.NumOut() != 2 || unwrap.Type.Out(0).Kind() != reflect.Bool {
+		return false
+	}
+
+	_, ok = reflect.PtrTo(t).MethodByName("SetSome")
+	return ok
+}
+
+func encodeValue(v reflect.Value) (interface{}, error) {
+	if !v.IsValid() {
+		return nil, nil
+	}
+
+	t := v.Type()
+	if (t.Kind() == reflect.Interface || t.Kind() == reflect.Ptr) && v.IsNil() {
+		return nil, nil
+	}
+
+	// never walk into keys
+	if t.Implements(identityType) {
+		return v.Interface().(Identity).Address(), nil
+	}
+
+	if t == keyringPairType {
+		return v.Interface().(signature.KeyringPair).Address, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Interface:
+		if t == errorType {
+			return v.Interface().(error).Error(), nil
+		}
+
+		return encodeValue(v.Elem())
+	case reflect.Ptr:
+		if t == bigIntType {
+			return v.Interface().(*big.Int).String(), nil
+		}
+
+		return encodeValue(v.Elem())
+	}
+
+	switch t {
+	case eventRecordsType:
+		records := v.Interface().(EventRecords)
+		return encodeValue(reflect.ValueOf((&EventFilter{}).Events(&records)))
+	case accountType:
+		return v.Interface().(AccountID).String(), nil
+	case rawAccountType:
+		return AccountID(v.Interface().(types.AccountID)).String(), nil
+	case hexHashType:
+		hash := v.Interface().(HexHash)
+		return strings.TrimRight(string(hash[:]), "\x00"), nil
+	case u128Type:
+		return bigString(v.Interface().(types.U128).Int), nil
+	case u256Type:
+		return bigString(v.Interface().(types.U256).Int), nil
+	case i128Type:
+		return bigString(v.Interface().(types.I128).Int), nil
+	case i256Type:
+		return bigString(v.Interface().(types.I256).Int), nil
+	case compactType:
+		value := big.Int(v.Interface().(types.UCompact))
+		return value.String(), nil
+	}
+
+	if isOption(t) {
+		if !v.Field(0).Bool() {
+			return nil, nil
+		}
+
+		return encodeValue(v.Field(1))
+	}
+
+	if isRawOption(t) {
+		out := v.MethodByName("Unwrap").Call(nil)
+		if !out[0].Bool() {
+			return nil, nil
+		}
+
+		return encodeValue(out[1])
+	}
+
+	if variants, ok := enumVariants(t); ok {
+		return encodeEnum(v, variants)
+	}
+
+	// structs of the package are always walked, their MarshalJSON
+	// methods use this encoder
+	if (t.Kind() != reflect.Struct || t.PkgPath() != packagePath) && t.Implements(marshalerType) {
+		data, err := v.Interface().(json.Marshaler).MarshalJSON()
+		return json.RawMessage(data), err
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		var object jsonObject
+		if err := encodeFields(v, &object); err != nil {
+			return nil, err
+		}
+
+		return object, nil
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil, nil
+		}
+
+		if t.Elem().Kind() == reflect.Uint8 {
+			return types.HexEncodeToString(v.Bytes()), nil
+		}
+
+		return encodeItems(v)
+	case reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			data := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(data), v)
+			return types.HexEncodeToString(data), nil
+		}
+
+		return encodeItems(v)
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("unsupported map key type %v", t.Key())
+		}
+
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+
+		object := make(jsonObject, 0, len(keys))
+		for _, key := range keys {
+			value, err := encodeValue(v.MapIndex(key))
+			if err != nil {
+				return nil, err
+			}
+			object = append(object, jsonField{key: key.String(), value: value})
+		}
+
+		return object, nil
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return v.Interface(), nil
+	}
+
+	return nil, fmt.Errorf("unsupported type %v", t)
+}
+
+func bigString(i *big.Int) string {
+	if i == nil {
+		return "0"
+	}
+
+	return i.String()
+}
+
+func encodeEnum(v reflect.Value, variants []enumVariant) (interface{}, error) {
+	for _, variant := range variants {
+		if !v.Field(variant.flag).Bool() {
+			continue
+		}
+
+		switch len(variant.value) {
+		case 0:
+			return variant.name, nil
+		case 1:
+			value, err := encodeValue(v.Field(variant.value[0]))
+			if err != nil {
+				return nil, err
+			}
+
+			return jsonObject{{key: variant.name, value: value}}, nil
+		}
+
+		object := make(jsonObject, 0, len(variant.value))
+		for _, i := range variant.value {
+			value, err := encodeValue(v.Field(i))
+			if err != nil {
+				return nil, err
+			}
+			object = append(object, jsonField{key: v.Type().Field(i).Name, value: value})
+		}
+
+		return jsonObject{{key: variant.name, value: object}}, nil
+	}
+
+	return nil, nil
+}
+
+func encodeItems(v reflect.Value) (interface{}, error) {
+	items := make([]interface{}, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		item, err := encodeValue(v.Index(i))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to encode item %d", i)
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// jsonName returns the key of a struct field, or false if it is skipped
+func jsonName(field reflect.StructField) (string, bool) {
+	if field.PkgPath != "" {
+		return "", false
+	}
+
+	switch field.Type.Kind() {
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return "", false
+	}
+
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "-" {
+		return "", false
+	}
+
+	if name == "" {
+		name = field.Name
+	}
+
+	return name, true
+}
+
+// isEmbedded checks for embedded structs whose fields are flattened
+func isEmbedded(field reflect.StructField) bool {
+	if !field.Anonymous || field.Type.Kind() != reflect.Struct || field.Type == keyringPairType {
+		return false
+	}
+
+	_, enum := enumVariants(field.Type)
+	return !enum && !isOption(field.Type)
+}
+
+func encodeFields(v reflect.Value, object *jsonObject) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if isEmbedded(field) {
+			if err := encodeFields(v.Field(i), object); err != nil {
+				return err
+			}
+			continue
+		}
+
+		name, ok := jsonName(field)
+		if !ok {
+			continue
+		}
+
+		value, err := encodeValue(v.Field(i))
+		if err != nil {
+			return errors.Wrapf(err, "failed to encode field %s", field.Name)
+		}
+
+		*object = append(*object, jsonField{key: name, value: value})
+	}
+
+	return nil
+}
+
+func decodeValue(data []byte, v reflect.Value) error {
+	t := v.Type()
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		v.Set(reflect.Zero(t))
+		return nil
+	}
+
+	// identities are encoded as their address only
+	if t.Implements(identityType) || t == keyringPairType {
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Interface:
+		if t == errorType {
+			var msg string
+			if err := json.Unmarshal(data, &msg); err != nil {
+				return err
+			}
+
+			v.Set(reflect.ValueOf(errors.New(msg)))
+			return nil
+		}
+
+		if t.NumMethod() != 0 {
+			return fmt.Errorf("unsupported interface type %v", t)
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+
+		var value interface{}
+		if err := decoder.Decode(&value); err != nil {
+			return err
+		}
+
+		v.Set(reflect.ValueOf(value))
+		return nil
+	case reflect.Ptr:
+		if t == bigIntType {
+			value, err := decodeBig(data)
+			if err != nil {
+				return err
+			}
+
+			v.Set(reflect.ValueOf(value))
+			return nil
+		}
+
+		value := reflect.New(t.Elem())
+		if err := decodeValue(data, value.Elem()); err != nil {
+			return err
+		}
+
+		v.Set(value)
+		return nil
+	}
+
+	switch t {
+	case eventRecordsType:
+		return decodeEventRecordsJSON(data, v.Addr().Interface().(*EventRecords))
+	case filteredEventType:
+		return decodeFilteredEvent(data, v.Addr().Interface().(*FilteredEvent))
+	case accountType, rawAccountType:
+		var address string
+		if err := json.Unmarshal(data, &address); err != nil {
+			return err
+		}
+
+		account, err := FromAddress(address)
+		if err != nil {
+			return errors.Wrapf(err, "invalid address %q", address)
+		}
+
+		v.Set(reflect.ValueOf(account).Convert(t))
+		return nil
+	case hexHashType:
+		var hash string
+		if err := json.Unmarshal(data, &hash); err != nil {
+			return err
+		}
+
+		var value HexHash
+		if len(hash) > len(value) {
+			return fmt.Errorf("hash %q is longer than %d bytes", hash, len(value))
+		}
+
+		copy(value[:], hash)
+		v.Set(reflect.ValueOf(value))
+		return nil
+	case u128Type, u256Type, i128Type, i256Type, compactType:
+		value, err := decodeBig(data)
+		if err != nil {
+			return err
+		}
+
+		switch t {
+		case u128Type:
+			v.Set(reflect.ValueOf(types.NewU128(*value)))
+		case u256Type:
+			v.Set(reflect.ValueOf(types.NewU256(*value)))
+		case i128Type:
+			v.Set(reflect.ValueOf(types.NewI128(*value)))
+		case i256Type:
+			v.Set(reflect.ValueOf(types.NewI256(*value)))
+		case compactType:
+			v.Set(reflect.ValueOf(types.NewUCompact(value)))
+		}
+
+		return nil
+	}
+
+	if isOption(t) {
+		v.Set(reflect.Zero(t))
+		if err := decodeValue(data, v.Field(1)); err != nil {
+			return err
+		}
+
+		v.Field(0).SetBool(true)
+		return nil
+	}
+
+	if isRawOption(t) {
+		unwrap, _ := t.MethodByName("Unwrap")
+		value := reflect.New(unwrap.Type.Out(1)).Elem()
+		if err := decodeValue(data, value); err != nil {
+			return err
+		}
+
+		v.Set(reflect.Zero(t))
+		v.Addr().MethodByName("SetSome").Call([]reflect.Value{value})
+		return nil
+	}
+
+	if variants, ok := enumVariants(t); ok {
+		return decodeEnum(data, v, variants)
+	}
+
+	if (t.Kind() != reflect.Struct || t.PkgPath() != packagePath) && reflect.PtrTo(t).Implements(unmarshalerType) {
+		return v.Addr().Interface().(json.Unmarshaler).UnmarshalJSON(data)
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return err
+		}
+
+		return decodeJSONFields(fields, v)
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			value, err := decodeHex(data)
+			if err != nil {
+				return err
+			}
+
+			v.Set(reflect.ValueOf(value).Convert(t))
+			return nil
+		}
+
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return err
+		}
+
+		v.Set(reflect.MakeSlice(t, len(items), len(items)))
+		return decodeJSONItems(items, v)
+	case reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			value, err := decodeHex(data)
+			if err != nil {
+				return err
+			}
+
+			if len(value) != v.Len() {
+				return fmt.Errorf("expected %d bytes, got %d", v.Len(), len(value))
+			}
+
+			reflect.Copy(v, reflect.ValueOf(value))
+			return nil
+		}
+
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return err
+		}
+
+		if len(items) != v.Len() {
+			return fmt.Errorf("expected %d items, got %d", v.Len(), len(items))
+		}
+
+		return decodeJSONItems(items, v)
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return fmt.Errorf("unsupported map key type %v", t.Key())
+		}
+
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return err
+		}
+
+		m := reflect.MakeMapWithSize(t, len(fields))
+		for key, raw := range fields {
+			value := reflect.New(t.Elem()).Elem()
+			if err := decodeValue(raw, value); err != nil {
+				return errors.Wrapf(err, "failed to decode %s", key)
+			}
+
+			m.SetMapIndex(reflect.ValueOf(key).Convert(t.Key()), value)
+		}
+
+		v.Set(m)
+		return nil
+	}
+
+	return json.Unmarshal(data, v.Addr().Interface())
+}
+
+func decodeBig(data []byte) (*big.Int, error) {
+	text := string(bytes.Trim(bytes.TrimSpace(data), `"`))
+
+	value, ok := new(big.Int).SetString(text, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid integer %s", data)
+	}
+
+	return value, nil
+}
+
+func decodeHex(data []byte) ([]byte, error) {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return nil, err
+	}
+
+	return types.HexDecodeString(text)
+}
+
+func decodeJSONItems(items []json.RawMessage, v reflect.Value) error {
+	for i, item := range items {
+		if err := decodeValue(item, v.Index(i)); err != nil {
+			return errors.Wrapf(err, "failed to decode item %d", i)
+		}
+	}
+
+	return nil
+}
+
+func decodeEnum(data []byte, v reflect.Value, variants []enumVariant) error {
+	var (
+		name  string
+		value json.RawMessage
+	)
+
+	if err := json.Unmarshal(data, &name); err != nil {
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(data, &object); err != nil || len(object) != 1 {
+			return fmt.Errorf("expected a variant name or an object with a single variant, got %s", data)
+		}
+
+		for name, value = range object {
+		}
+	}
+
+	v.Set(reflect.Zero(v.Type()))
+	for _, variant := range variants {
+		if variant.name != name {
+			continue
+		}
+
+		v.Field(variant.flag).SetBool(true)
+		switch {
+		case len(variant.value) == 0 || value == nil:
+			return nil
+		case len(variant.value) == 1:
+			return decodeValue(value, v.Field(variant.value[0]))
+		}
+
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(value, &fields); err != nil {
+			return err
+		}
+
+		for _, i := range variant.value {
+			raw, ok := fields[v.Type().Field(i).Name]
+			if !ok {
+				continue
+			}
+
+			if err := decodeValue(raw, v.Field(i)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("unknown variant %q of %v", name, v.Type())
+}
+
+func decodeJSONFields(fields map[string]json.RawMessage, v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if isEmbedded(field) {
+			if err := decodeJSONFields(fields, v.Field(i)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		name, ok := jsonName(field)
+		if !ok {
+			continue
+		}
+
+		raw, ok := fields[name]
+		if !ok {
+			continue
+		}
+
+		if err := decodeValue(raw, v.Field(i)); err != nil {
+			return errors.Wrapf(err, "failed to decode field %s", name)
+		}
+	}
+
+	return nil
+}
+
+// eventType returns the type of an event from its EventRecords field name,
+// events without a field are DynamicEvent
+func eventType(name string) reflect.Type {
+	if field, ok := eventRecordsType.FieldByName(name); ok && strings.Contains(name, "_") &&
+		field.Type.Kind() == reflect.Slice && checkEventType(field.Type.Elem()) == nil {
+		return field.Type.Elem()
+	}
+
+	return reflect.TypeOf(DynamicEvent{})
+}
+
+func decodeFilteredEvent(data []byte, event *FilteredEvent) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	raw := fields["Event"]
+	delete(fields, "Event")
+
+	*event = FilteredEvent{}
+	if err := decodeJSONFields(fields, reflect.ValueOf(event).Elem()); err != nil {
+		return err
+	}
+
+	if raw == nil {
+		return nil
+	}
+
+	value := reflect.New(eventType(event.Name)).Elem()
+	if err := decodeValue(raw, value); err != nil {
+		return errors.Wrapf(err, "failed to decode event %s", event.Name)
+	}
+
+	event.Event = value.Interface()
+	return nil
+}
+
+func decodeEventRecordsJSON(data []byte, records *EventRecords) error {
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+
+	*records = EventRecords{}
+	val := reflect.ValueOf(records).Elem()
+	for i, item := range items {
+		var event FilteredEvent
+		if err := decodeFilteredEvent(item, &event); err != nil {
+			return errors.Wrapf(err, "failed to decode event #%d", i)
+		}
+
+		value := reflect.ValueOf(event.Event)
+		if !value.IsValid() {
+			return fmt.Errorf("event #%d (%s) has no value", i, event.Name)
+		}
+
+		phase := value.Field(0).Interface().(types.Phase)
+		if dynamic, ok := event.Event.(DynamicEvent); ok {
+			records.Order = append(records.Order, EventRef{Phase: phase, Name: event.Name, Index: len(records.Dynamic)})
+			records.Dynamic = append(records.Dynamic, dynamic)
+			continue
+		}
+
+		field := val.FieldByName(event.Name)
+		records.Order = append(records.Order, EventRef{Phase: phase, Name: event.Name, Index: field.Len()})
+		field.Set(reflect.Append(field, value))
+	}
+
+	return nil
+}
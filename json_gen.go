@@ -0,0 +1,1043 @@
+// Code generated by gen_json.go; DO NOT EDIT.
+
+package substrate
+
+// MarshalJSON implementation
+func (v AccountInfo) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *AccountInfo) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v Approved) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *Approved) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v AveragePriceIsAboveMaxPrice) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *AveragePriceIsAboveMaxPrice) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v AveragePriceIsAboveMinPrice) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *AveragePriceIsAboveMinPrice) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v Balance) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *Balance) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v BatchResult) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *BatchResult) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v BillingFrequencyChanged) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *BillingFrequencyChanged) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v BlockEvents) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *BlockEvents) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v Bonded) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *Bonded) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v BridgeBurnTransactionCreated) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *BridgeBurnTransactionCreated) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v BridgeBurnTransactionExpired) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *BridgeBurnTransactionExpired) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v BurnTransaction) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *BurnTransaction) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v BurnTransactionCreated) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *BurnTransactionCreated) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v BurnTransactionProcessed) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *BurnTransactionProcessed) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v BurnTransactionProposed) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *BurnTransactionProposed) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v BurnTransactionReady) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *BurnTransactionReady) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v BurnTransactionSignatureAdded) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *BurnTransactionSignatureAdded) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v CallOptions) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *CallOptions) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v CallResponse) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *CallResponse) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v CertificationCodeStored) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *CertificationCodeStored) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v CertificationCodes) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *CertificationCodes) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v Checkpoint) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *Checkpoint) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v Closed) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *Closed) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v ClosedByCouncil) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *ClosedByCouncil) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v ConnectionPriceSet) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *ConnectionPriceSet) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v Consumption) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *Consumption) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v ConsumptionReportReceived) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *ConsumptionReportReceived) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v Contract) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *Contract) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v ContractBill) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *ContractBill) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v ContractBilled) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *ContractBilled) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v ContractCreated) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *ContractCreated) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v ContractCreatedV1) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *ContractCreatedV1) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v ContractDeployed) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *ContractDeployed) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v ContractGracePeriodEnded) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *ContractGracePeriodEnded) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v ContractGracePeriodStarted) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *ContractGracePeriodStarted) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v ContractResources) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *ContractResources) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v ContractState) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *ContractState) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v ContractType) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *ContractType) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v ContractUpdated) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *ContractUpdated) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v ContractUpdatedV1) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *ContractUpdatedV1) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v ContractV1) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *ContractV1) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v CouncilMemberVeto) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *CouncilMemberVeto) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v DeletedState) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *DeletedState) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v Disapproved) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *Disapproved) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v DiscountLevel) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *DiscountLevel) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v DynamicEvent) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *DynamicEvent) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v Entity) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *Entity) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v EntityDeleted) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *EntityDeleted) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v EntityProof) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *EntityProof) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v EntityStored) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *EntityStored) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v EntryEvent) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *EntryEvent) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v EventFilter) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *EventFilter) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v EventRecords) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *EventRecords) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v EventRef) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *EventRef) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v Executed) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *Executed) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v Farm) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *Farm) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v FarmCertification) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *FarmCertification) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v FarmCertificationSet) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *FarmCertificationSet) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v FarmDeleted) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *FarmDeleted) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v FarmMarkedAsDedicated) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *FarmMarkedAsDedicated) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v FarmPayoutV2AddressRegistered) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *FarmPayoutV2AddressRegistered) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v FarmStored) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *FarmStored) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v FarmingPolicy) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *FarmingPolicy) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v FarmingPolicyLimit) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *FarmingPolicyLimit) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v FarmingPolicySet) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *FarmingPolicySet) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v FarmingPolicyStored) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *FarmingPolicyStored) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v FarmingPolicyUpdated) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *FarmingPolicyUpdated) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v FeeInfo) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *FeeInfo) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v FilteredEvent) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *FilteredEvent) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v IP) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *IP) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v IPsFreed) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *IPsFreed) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v IPsReserved) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *IPsReserved) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v Interface) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *Interface) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v IterOptions) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *IterOptions) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v Location) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *Location) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v LocationV1) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *LocationV1) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v ManagerOptions) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *ManagerOptions) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v MemberEvent) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *MemberEvent) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v MintCompleted) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *MintCompleted) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v MintTransaction) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *MintTransaction) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v MintTransactionExpired) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *MintTransactionExpired) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v MintTransactionProposed) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *MintTransactionProposed) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v MintTransactionVoted) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *MintTransactionVoted) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v ModuleError) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *ModuleError) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v NameContract) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *NameContract) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v NameContractCanceled) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *NameContractCanceled) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v Node) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *Node) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v NodeCertification) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *NodeCertification) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v NodeCertificationSet) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *NodeCertificationSet) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v NodeCertifierAdded) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *NodeCertifierAdded) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v NodeCertifierRemoved) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *NodeCertifierRemoved) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v NodeContract) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *NodeContract) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v NodeContractCanceled) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *NodeContractCanceled) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v NodeDeleted) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *NodeDeleted) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v NodeExtra) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *NodeExtra) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v NodeMarkAsDedicated) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *NodeMarkAsDedicated) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v NodePower) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *NodePower) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v NodePublicConfig) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *NodePublicConfig) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v NodeStored) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *NodeStored) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v NodeStoredV1) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *NodeStoredV1) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v NodeStoredV2) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *NodeStoredV2) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v NodeUptimeReported) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *NodeUptimeReported) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v NodeV1) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *NodeV1) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v NodeV2) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *NodeV2) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v NruConsumption) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *NruConsumption) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v NruConsumptionReportReceived) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *NruConsumptionReportReceived) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v OffchainWorkerExecuted) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *OffchainWorkerExecuted) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v OptionBoardSerial) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *OptionBoardSerial) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v OptionDomain) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *OptionDomain) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v OptionFarmingPolicyLimit) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *OptionFarmingPolicyLimit) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v OptionIP) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *OptionIP) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v OptionPublicConfig) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *OptionPublicConfig) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v OptionPublicConfigV1) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *OptionPublicConfigV1) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v OptionRelay) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *OptionRelay) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v Policy) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *Policy) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v Power) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *Power) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v PowerState) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *PowerState) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v PowerStateChanged) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *PowerStateChanged) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v PowerTargetChanged) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *PowerTargetChanged) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v PriceStored) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *PriceStored) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v PricingPolicy) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *PricingPolicy) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v PricingPolicyStored) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *PricingPolicyStored) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v ProcessorOptions) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *ProcessorOptions) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v Proposed) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *Proposed) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v Provider) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *Provider) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v PublicConfig) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *PublicConfig) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v PublicConfigV1) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *PublicConfigV1) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v PublicIP) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *PublicIP) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v PublicIPInput) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *PublicIPInput) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v RangeOptions) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *RangeOptions) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v RefundTransaction) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *RefundTransaction) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v RefundTransactionCreated) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *RefundTransactionCreated) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v RefundTransactionProcessed) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *RefundTransactionProcessed) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v RefundTransactionReady) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *RefundTransactionReady) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v RefundTransactionSignatureAdded) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *RefundTransactionSignatureAdded) UnmarshalJSON(data []byte) error {
+	return DecodeJSON(data, v)
+}
+
+// MarshalJSON implementation
+func (v RentContract) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *RentContract) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v RentContractCanceled) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *RentContractCanceled) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v Resources) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *Resources) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v Role) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *Role) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v ScannedContract) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *ScannedContract) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v ScannedFarm) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *ScannedFarm) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v ScannedNode) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *ScannedNode) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v ScannedTwin) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *ScannedTwin) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v ServiceContract) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *ServiceContract) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v ServiceContractBill) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *ServiceContractBill) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v ServiceContractBilled) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *ServiceContractBilled) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v ServiceContractCanceled) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *ServiceContractCanceled) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v ServiceContractCreated) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *ServiceContractCreated) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v ServiceContractState) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *ServiceContractState) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v SolutionProvider) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *SolutionProvider) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v SolutionProviderApproved) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *SolutionProviderApproved) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v SolutionProviderCreated) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *SolutionProviderCreated) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v StellarSignature) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *StellarSignature) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v StorageEntry) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *StorageEntry) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v SubscribeOptions) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *SubscribeOptions) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v TermsAndConditions) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *TermsAndConditions) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v TokensBurned) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *TokensBurned) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v Twin) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *Twin) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v TwinDeleted) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *TwinDeleted) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v TwinEntityRemoved) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *TwinEntityRemoved) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v TwinEntityStored) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *TwinEntityStored) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v TwinStored) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *TwinStored) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v UpdatedUsedResources) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *UpdatedUsedResources) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v User) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *User) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v Validator) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *Validator) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v ValidatorAdded) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *ValidatorAdded) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v ValidatorApproved) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *ValidatorApproved) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v ValidatorCreated) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *ValidatorCreated) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v ValidatorRemoved) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *ValidatorRemoved) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v ValidatorRequestState) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *ValidatorRequestState) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v Versioned) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *Versioned) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v Voted) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *Voted) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
+
+// MarshalJSON implementation
+func (v ZosVersionUpdated) MarshalJSON() ([]byte, error) { return EncodeJSON(v) }
+
+// UnmarshalJSON implementation
+func (v *ZosVersionUpdated) UnmarshalJSON(data []byte) error { return DecodeJSON(data, v) }
@@ -0,0 +1,253 @@
+package substrate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONEncoding(t *testing.T) {
+	identity, err := NewIdentityFromSr25519Phrase("//Alice")
+	require.NoError(t, err)
+	account, err := FromAddress(identity.Address())
+	require.NoError(t, err)
+
+	amount, ok := new(big.Int).SetString("1000000000000000000000", 10)
+	require.True(t, ok)
+
+	contract := Contract{
+		Versioned:    Versioned{Version: 4},
+		State:        ContractState{IsGracePeriod: true, AsGracePeriodBlockNumber: 1200},
+		ContractID:   10,
+		TwinID:       1,
+		ContractType: ContractType{IsNodeContract: true, NodeContract: NodeContract{Node: 5, DeploymentHash: HexHash{'a', 'b'}}},
+	}
+
+	data, err := EncodeJSON(contract)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"Version": 4,
+		"State": {"GracePeriod": 1200},
+		"ContractID": 10,
+		"TwinID": 1,
+		"ContractType": {"NodeContract": {"Node": 5, "DeploymentHash": "ab", "DeploymentData": "", "PublicIPsCount": 0, "PublicIPs": null}},
+		"SolutionProviderID": null
+	}`, string(data))
+
+	var decoded Contract
+	require.NoError(t, DecodeJSON(data, &decoded))
+	require.Equal(t, contract, decoded)
+
+	bill := ContractBilled{
+		Phase:        types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: 2},
+		ContractBill: ContractBill{ContractID: 10, DiscountLevel: DiscountLevel{IsGold: true}, AmountBilled: types.NewU128(*amount)},
+	}
+
+	data, err = EncodeJSON(bill)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"Phase": {"ApplyExtrinsic": 2},
+		"ContractBill": {"ContractID": 10, "Timestamp": 0, "DiscountLevel": "Gold", "AmountBilled": "1000000000000000000000"},
+		"Topics": null
+	}`, string(data))
+
+	var decodedBill ContractBilled
+	require.NoError(t, DecodeJSON(data, &decodedBill))
+	require.Equal(t, bill, decodedBill)
+
+	twin := Twin{ID: 1, Account: account, Relay: OptionRelay{HasValue: true, AsValue: "relay.grid.tf"}, Pk: types.NewOptionBytes([]byte{1, 2})}
+	data, err = EncodeJSON(twin)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"ID": 1,
+		"Account": "`+identity.Address()+`",
+		"Relay": "relay.grid.tf",
+		"Entities": null,
+		"Pk": "0x0102"
+	}`, string(data))
+
+	var decodedTwin Twin
+	require.NoError(t, DecodeJSON(data, &decodedTwin))
+	require.Equal(t, twin, decodedTwin)
+
+	// enums and options also work with encoding/json
+	data, err = json.Marshal(PowerState{IsDown: true, AsDownBlockNumber: 7})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"Down": 7}`, string(data))
+
+	var power Power
+	require.NoError(t, json.Unmarshal([]byte(`"Up"`), &power))
+	require.Equal(t, Power{IsUp: true}, power)
+	require.Error(t, json.Unmarshal([]byte(`"Sideways"`), &power))
+
+	data, err = json.Marshal(OptionDomain{})
+	require.NoError(t, err)
+	require.Equal(t, "null", string(data))
+
+	// identities are never encoded with their keys
+	data, err = EncodeJSON(CallResponse{Identity: identity, Finality: WaitFinalized})
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "//Alice")
+	require.Contains(t, string(data), `"Identity":"`+identity.Address()+`"`)
+	require.Contains(t, string(data), `"Finality":"finalized"`)
+}
+
+func TestEventRecordsNDJSON(t *testing.T) {
+	phase := types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: 1}
+
+	events := EventRecords{
+		TfgridModule_NodeDeleted: []NodeDeleted{{Phase: phase, Node: 5}},
+		Dynamic: []DynamicEvent{{
+			Phase:  types.Phase{IsFinalization: true},
+			Pallet: "NewPallet",
+			Name:   "Happened",
+			Fields: map[string]interface{}{"who": "0x01"},
+		}},
+	}
+	events.Balances_Transfer = []types.EventBalancesTransfer{{Phase: phase, From: types.AccountID{1}, To: types.AccountID{2}, Value: types.NewU128(*big.NewInt(5))}}
+	events.Order = []EventRef{
+		{Phase: phase, Name: "TfgridModule_NodeDeleted"},
+		{Phase: types.Phase{IsFinalization: true}, Name: "NewPallet_Happened"},
+		{Phase: phase, Name: "Balances_Transfer"},
+	}
+
+	block := BlockEvents{Number: 42, Hash: types.Hash{1}, Events: &events}
+
+	var buf bytes.Buffer
+	require.NoError(t, block.WriteNDJSON(&buf))
+	require.Equal(t, 3, bytes.Count(buf.Bytes(), []byte("\n")))
+
+	read, err := ReadNDJSON(&buf)
+	require.NoError(t, err)
+	require.Len(t, read, 3)
+	require.Equal(t, uint32(42), read[0].Block)
+	require.Equal(t, types.Hash{1}, read[0].Hash)
+	require.Equal(t, 1, read[0].Extrinsic)
+	require.Equal(t, events.TfgridModule_NodeDeleted[0], read[0].Event)
+	require.Equal(t, -1, read[1].Extrinsic)
+	require.Equal(t, events.Dynamic[0], read[1].Event)
+	require.Equal(t, events.Balances_Transfer[0], read[2].Event)
+
+	// records encode as the list of their events
+	data, err := json.Marshal(events)
+	require.NoError(t, err)
+
+	var decoded EventRecords
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, events.Order, decoded.Order)
+	require.Equal(t, events.TfgridModule_NodeDeleted, decoded.TfgridModule_NodeDeleted)
+	require.Equal(t, events.Balances_Transfer, decoded.Balances_Transfer)
+	require.Equal(t, events.Dynamic, decoded.Dynamic)
+
+	data, err = EncodeJSON(BlockEvents{Number: 1, Err: errors.New("connection lost")})
+	require.NoError(t, err)
+
+	var failed BlockEvents
+	require.NoError(t, DecodeJSON(data, &failed))
+	require.EqualError(t, failed.Err, "connection lost")
+	require.Nil(t, failed.Events)
+}
+
+func TestJSONMarshalStructs(t *testing.T) {
+	identity, err := NewIdentityFromSr25519Phrase("//Alice")
+	require.NoError(t, err)
+	account, err := FromAddress(identity.Address())
+	require.NoError(t, err)
+
+	balance := Balance{Free: types.NewU128(*big.NewInt(1234567))}
+	data, err := json.Marshal(balance)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"Free": "1234567", "Reserved": "0", "MiscFrozen": "0", "FreeFrozen": "0"}`, string(data))
+
+	var decodedBalance Balance
+	require.NoError(t, json.Unmarshal(data, &decodedBalance))
+	require.Equal(t, "1234567", decodedBalance.Free.String())
+
+	var info AccountInfo
+	info.Nonce = 3
+	info.Data.Free = types.NewU128(*big.NewInt(42))
+	data, err = json.Marshal(info)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"Nonce": 3, "Consumers": 0, "Providers": 0, "Sufficients": 0,
+		"Data": {"Free": "42", "Reserved": "0", "MiscFrozen": "0", "FreeFrozen": "0"}
+	}`, string(data))
+
+	// nested in a struct without MarshalJSON
+	data, err = json.Marshal(struct {
+		Bill ContractBill
+	}{ContractBill{ContractID: 1, DiscountLevel: DiscountLevel{IsNone: true}, AmountBilled: types.NewU128(*big.NewInt(10))}})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"Bill": {"ContractID": 1, "Timestamp": 0, "DiscountLevel": "None", "AmountBilled": "10"}}`, string(data))
+
+	limit := FarmingPolicyLimit{FarmingPolicyID: 1, Cu: types.NewOptionU64(20), NodeCount: types.NewOptionU32Empty()}
+	data, err = json.Marshal(limit)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"FarmingPolicyID": 1, "Cu": 20, "Su": null, "End": null, "NodeCount": null, "NodeCertification": false}`, string(data))
+
+	var decodedLimit FarmingPolicyLimit
+	require.NoError(t, json.Unmarshal(data, &decodedLimit))
+	require.Equal(t, limit, decodedLimit)
+
+	mint := MintTransaction{Amount: 5, Target: types.AccountID(account)}
+	data, err = json.Marshal(mint)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"Amount": 5, "Target": "`+identity.Address()+`", "Block": 0, "Votes": 0}`, string(data))
+
+	contract := Contract{
+		State:              ContractState{IsCreated: true},
+		ContractType:       ContractType{IsNameContract: true, NameContract: NameContract{Name: "example"}},
+		SolutionProviderID: types.NewOptionU64(7),
+	}
+	data, err = json.Marshal(contract)
+	require.NoError(t, err)
+
+	var decodedContract Contract
+	require.NoError(t, json.Unmarshal(data, &decodedContract))
+	require.Equal(t, contract, decodedContract)
+}
+
+func TestJSONMarshalMatchesEncodeJSON(t *testing.T) {
+	extrinsic := types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: 1}
+	for _, v := range []interface{}{
+		NodeStored{Phase: extrinsic, Node: Node{ID: 1, Certification: NodeCertification{IsDiy: true}}},
+		Farm{ID: 1, Name: "farm", PublicIPs: []PublicIP{{IP: "185.206.122.33/24", Gateway: "185.206.122.1"}}},
+		ContractCreated{Phase: extrinsic, Contract: Contract{State: ContractState{IsCreated: true}}},
+		Checkpoint{Number: 10},
+	} {
+		expected, err := EncodeJSON(v)
+		require.NoError(t, err)
+
+		data, err := json.Marshal(v)
+		require.NoError(t, err)
+		require.JSONEq(t, string(expected), string(data), "%T", v)
+	}
+
+	data, err := json.Marshal(NodeStored{Phase: extrinsic})
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"Phase":{"ApplyExtrinsic":1}`)
+}
+
+func TestJSONGenerated(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs the generator")
+	}
+
+	output := filepath.Join(t.TempDir(), "json_gen")
+	out, err := exec.Command("go", "run", "gen_json.go", output).CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	expected, err := os.ReadFile(output)
+	require.NoError(t, err)
+	actual, err := os.ReadFile("json_gen.go")
+	require.NoError(t, err)
+	require.Equal(t, string(expected), string(actual), "json_gen.go is outdated, run go generate")
+}
@@ -0,0 +1,318 @@
+package substrate
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// runtime spec versions where the layouts of contracts and nodes changed,
+// events of older runtimes are decoded with the legacy types below. The
+// spec_version of each tfchain runtime is set in
+// substrate-node/runtime/src/lib.rs. With v14 metadata the layout is also
+// checked against the event fields of the runtime, so a boundary that is
+// off fails or falls back to the layout of the runtime instead of
+// misdecoding the events
+const (
+	// specContractSolutionProvider added solution_provider_id to contracts
+	specContractSolutionProvider = 105
+	// specNodePublicConfigIPs changed the node public config to ip/gw pairs
+	specNodePublicConfigIPs = 118
+	// specNodeLocation moved country and city into the node location and
+	// made the serial number optional
+	specNodeLocation = 125
+)
+
+// legacyEventTypes are the older layouts registered in DefaultEventRegistry
+var legacyEventTypes = []EventType{
+	{
+		Pallet:  "SmartContractModule",
+		Event:   "ContractCreated",
+		MinSpec: 1,
+		MaxSpec: specContractSolutionProvider - 1,
+		Type:    reflect.TypeOf(ContractCreatedV1{}),
+		Upgrade: func(event interface{}) interface{} { return event.(ContractCreatedV1).Upgrade() },
+	},
+	{
+		Pallet:  "SmartContractModule",
+		Event:   "ContractUpdated",
+		MinSpec: 1,
+		MaxSpec: specContractSolutionProvider - 1,
+		Type:    reflect.TypeOf(ContractUpdatedV1{}),
+		Upgrade: func(event interface{}) interface{} { return event.(ContractUpdatedV1).Upgrade() },
+	},
+	{
+		Pallet:  "TfgridModule",
+		Event:   "NodeStored",
+		MinSpec: 1,
+		MaxSpec: specNodePublicConfigIPs - 1,
+		Type:    reflect.TypeOf(NodeStoredV1{}),
+		Upgrade: func(event interface{}) interface{} { return event.(NodeStoredV1).Upgrade() },
+	},
+	{
+		Pallet:  "TfgridModule",
+		Event:   "NodeUpdated",
+		MinSpec: 1,
+		MaxSpec: specNodePublicConfigIPs - 1,
+		Type:    reflect.TypeOf(NodeStoredV1{}),
+		Upgrade: func(event interface{}) interface{} { return event.(NodeStoredV1).Upgrade() },
+	},
+	{
+		Pallet:  "TfgridModule",
+		Event:   "NodeStored",
+		MinSpec: specNodePublicConfigIPs,
+		MaxSpec: specNodeLocation - 1,
+		Type:    reflect.TypeOf(NodeStoredV2{}),
+		Upgrade: func(event interface{}) interface{} { return event.(NodeStoredV2).Upgrade() },
+	},
+	{
+		Pallet:  "TfgridModule",
+		Event:   "NodeUpdated",
+		MinSpec: specNodePublicConfigIPs,
+		MaxSpec: specNodeLocation - 1,
+		Type:    reflect.TypeOf(NodeStoredV2{}),
+		Upgrade: func(event interface{}) interface{} { return event.(NodeStoredV2).Upgrade() },
+	},
+}
+
+// ContractV1 is the layout of contracts before solution providers
+type ContractV1 struct {
+	Versioned
+	State        ContractState
+	ContractID   types.U64
+	TwinID       types.U32
+	ContractType ContractType
+}
+
+// Upgrade converts the contract to the latest layout
+func (c ContractV1) Upgrade() Contract {
+	return Contract{
+		Versioned:    c.Versioned,
+		State:        c.State,
+		ContractID:   c.ContractID,
+		TwinID:       c.TwinID,
+		ContractType: c.ContractType,
+	}
+}
+
+// ContractCreatedV1 is the contract created event with a ContractV1
+type ContractCreatedV1 struct {
+	Phase    types.Phase
+	Contract ContractV1
+	Topics   []types.Hash
+}
+
+// Upgrade converts the event to the latest layout
+func (e ContractCreatedV1) Upgrade() ContractCreated {
+	return ContractCreated{Phase: e.Phase, Contract: e.Contract.Upgrade(), Topics: e.Topics}
+}
+
+// ContractUpdatedV1 is the contract updated event with a ContractV1
+type ContractUpdatedV1 struct {
+	Phase    types.Phase
+	Contract ContractV1
+	Topics   []types.Hash
+}
+
+// Upgrade converts the event to the latest layout
+func (e ContractUpdatedV1) Upgrade() ContractUpdated {
+	return ContractUpdated{Phase: e.Phase, Contract: e.Contract.Upgrade(), Topics: e.Topics}
+}
+
+// LocationV1 is the node location before country and city were part of it
+type LocationV1 struct {
+	Longitude string
+	Latitude  string
+}
+
+// PublicConfigV1 is the node public config before ip/gw pairs
+type PublicConfigV1 struct {
+	IPv4   string
+	IPv6   string
+	GWv4   string
+	GWv6   string
+	Domain string
+}
+
+// Upgrade converts the public config to the latest layout
+func (c PublicConfigV1) Upgrade() PublicConfig {
+	cfg := PublicConfig{IP4: IP{IP: c.IPv4, GW: c.GWv4}}
+	if c.IPv6 != "" {
+		cfg.IP6 = OptionIP{HasValue: true, AsValue: IP{IP: c.IPv6, GW: c.GWv6}}
+	}
+	if c.Domain != "" {
+		cfg.Domain = OptionDomain{HasValue: true, AsValue: c.Domain}
+	}
+
+	return cfg
+}
+
+// OptionPublicConfigV1 type
+type OptionPublicConfigV1 struct {
+	HasValue bool
+	AsValue  PublicConfigV1
+}
+
+// Encode implementation
+func (m OptionPublicConfigV1) Encode(encoder scale.Encoder) (err error) {
+	var i byte
+	if m.HasValue {
+		i = 1
+	}
+	err = encoder.PushByte(i)
+	if err != nil {
+		return err
+	}
+
+	if m.HasValue {
+		err = encoder.Encode(m.AsValue)
+	}
+
+	return
+}
+
+// Decode implementation
+func (m *OptionPublicConfigV1) Decode(decoder scale.Decoder) (err error) {
+	var i byte
+	if err := decoder.Decode(&i); err != nil {
+		return err
+	}
+
+	switch i {
+	case 0:
+		return nil
+	case 1:
+		m.HasValue = true
+		return decoder.Decode(&m.AsValue)
+	default:
+		return fmt.Errorf("unknown value for Option")
+	}
+}
+
+// NodeV1 is the layout of nodes with a PublicConfigV1
+type NodeV1 struct {
+	Versioned
+	ID              types.U32
+	FarmID          types.U32
+	TwinID          types.U32
+	Resources       Resources
+	Location        LocationV1
+	Country         string
+	City            string
+	PublicConfig    OptionPublicConfigV1
+	Created         types.U64
+	FarmingPolicy   types.U32
+	Interfaces      []Interface
+	Certification   NodeCertification
+	SecureBoot      bool
+	Virtualized     bool
+	BoardSerial     string
+	ConnectionPrice types.U32
+}
+
+// Upgrade converts the node to the latest layout
+func (n NodeV1) Upgrade() Node {
+	var cfg OptionPublicConfig
+	if n.PublicConfig.HasValue {
+		cfg = OptionPublicConfig{HasValue: true, AsValue: n.PublicConfig.AsValue.Upgrade()}
+	}
+
+	return NodeV2{
+		Versioned:       n.Versioned,
+		ID:              n.ID,
+		FarmID:          n.FarmID,
+		TwinID:          n.TwinID,
+		Resources:       n.Resources,
+		Location:        n.Location,
+		Country:         n.Country,
+		City:            n.City,
+		PublicConfig:    cfg,
+		Created:         n.Created,
+		FarmingPolicy:   n.FarmingPolicy,
+		Interfaces:      n.Interfaces,
+		Certification:   n.Certification,
+		SecureBoot:      n.SecureBoot,
+		Virtualized:     n.Virtualized,
+		BoardSerial:     n.BoardSerial,
+		ConnectionPrice: n.ConnectionPrice,
+	}.Upgrade()
+}
+
+// NodeV2 is the layout of nodes before country and city were part of
+// the location
+type NodeV2 struct {
+	Versioned
+	ID              types.U32
+	FarmID          types.U32
+	TwinID          types.U32
+	Resources       Resources
+	Location        LocationV1
+	Country         string
+	City            string
+	PublicConfig    OptionPublicConfig
+	Created         types.U64
+	FarmingPolicy   types.U32
+	Interfaces      []Interface
+	Certification   NodeCertification
+	SecureBoot      bool
+	Virtualized     bool
+	BoardSerial     string
+	ConnectionPrice types.U32
+}
+
+// Upgrade converts the node to the latest layout
+func (n NodeV2) Upgrade() Node {
+	node := Node{
+		Versioned: n.Versioned,
+		ID:        n.ID,
+		FarmID:    n.FarmID,
+		TwinID:    n.TwinID,
+		Resources: n.Resources,
+		Location: Location{
+			City:      n.City,
+			Country:   n.Country,
+			Latitude:  n.Location.Latitude,
+			Longitude: n.Location.Longitude,
+		},
+		PublicConfig:    n.PublicConfig,
+		Created:         n.Created,
+		FarmingPolicy:   n.FarmingPolicy,
+		Interfaces:      n.Interfaces,
+		Certification:   n.Certification,
+		SecureBoot:      n.SecureBoot,
+		Virtualized:     n.Virtualized,
+		ConnectionPrice: n.ConnectionPrice,
+	}
+
+	if n.BoardSerial != "" {
+		node.BoardSerial = OptionBoardSerial{HasValue: true, AsValue: n.BoardSerial}
+	}
+
+	return node
+}
+
+// NodeStoredV1 is the node stored (and updated) event with a NodeV1
+type NodeStoredV1 struct {
+	Phase  types.Phase
+	Node   NodeV1
+	Topics []types.Hash
+}
+
+// Upgrade converts the event to the latest layout
+func (e NodeStoredV1) Upgrade() NodeStored {
+	return NodeStored{Phase: e.Phase, Node: e.Node.Upgrade(), Topics: e.Topics}
+}
+
+// NodeStoredV2 is the node stored (and updated) event with a NodeV2
+type NodeStoredV2 struct {
+	Phase  types.Phase
+	Node   NodeV2
+	Topics []types.Hash
+}
+
+// Upgrade converts the event to the latest layout
+func (e NodeStoredV2) Upgrade() NodeStored {
+	return NodeStored{Phase: e.Phase, Node: e.Node.Upgrade(), Topics: e.Topics}
+}
@@ -0,0 +1,157 @@
+package substrate
+
+import (
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// metaKey identifies the metadata of a runtime version
+// of a specific chain
+type metaKey struct {
+	genesis types.Hash
+	spec    types.U32
+}
+
+// metadataCache caches decoded metadata per chain and runtime version.
+// it's shared between all connections created by the same manager so
+// metadata is only downloaded once per runtime version.
+type metadataCache struct {
+	m       sync.Mutex
+	metas   map[metaKey]Meta
+	latest  map[types.Hash]types.U32
+	loading map[metaKey]chan struct{}
+}
+
+func newMetadataCache() *metadataCache {
+	return &metadataCache{
+		metas:   make(map[metaKey]Meta),
+		latest:  make(map[types.Hash]types.U32),
+		loading: make(map[metaKey]chan struct{}),
+	}
+}
+
+// get returns the metadata for the given spec version. If not cached, the metadata
+// is downloaded at block `at` which must be a block that runs this spec version. if
+// `at` is nil the latest metadata is downloaded, and the spec is marked as the latest
+// runtime version of the chain.
+func (c *metadataCache) get(cl Conn, genesis types.Hash, spec types.U32, at *types.Hash) (Meta, error) {
+	key := metaKey{genesis: genesis, spec: spec}
+
+	for {
+		c.m.Lock()
+		if at == nil && spec > c.latest[genesis] {
+			c.latest[genesis] = spec
+		}
+
+		if meta, ok := c.metas[key]; ok {
+			c.m.Unlock()
+			return meta, nil
+		}
+
+		wait, ok := c.loading[key]
+		if !ok {
+			break
+		}
+		c.m.Unlock()
+		// some other routine is already downloading this
+		// metadata, wait for it then try again.
+		<-wait
+	}
+
+	done := make(chan struct{})
+	c.loading[key] = done
+	c.m.Unlock()
+
+	defer func() {
+		c.m.Lock()
+		delete(c.loading, key)
+		c.m.Unlock()
+		close(done)
+	}()
+
+	log.Debug().Uint32("spec", uint32(spec)).Msg("downloading runtime metadata")
+	var (
+		meta Meta
+		err  error
+	)
+	if at == nil {
+		meta, err = cl.RPC.State.GetMetadataLatest()
+	} else {
+		meta, err = cl.RPC.State.GetMetadata(*at)
+	}
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get metadata for spec version '%d'", spec)
+	}
+
+	c.m.Lock()
+	c.metas[key] = meta
+	c.m.Unlock()
+
+	return meta, nil
+}
+
+// current returns the metadata of the latest known runtime version of the chain
+// or fallback if it's not known
+func (c *metadataCache) current(genesis types.Hash, fallback Meta) Meta {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	spec, ok := c.latest[genesis]
+	if !ok {
+		return fallback
+	}
+
+	if meta, ok := c.metas[metaKey{genesis: genesis, spec: spec}]; ok {
+		return meta
+	}
+
+	return fallback
+}
+
+// watch follows runtime version changes over the connection and updates the
+// latest metadata of the chain once a runtime upgrade happens. watch returns
+// a function that blocks until the connection is closed, so it can run in its
+// own routine.
+func (c *metadataCache) watch(cl Conn, genesis types.Hash) (func(), error) {
+	sub, err := cl.RPC.State.SubscribeRuntimeVersion()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to subscribe to runtime version changes")
+	}
+
+	return func() {
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case err := <-sub.Err():
+				if err != nil {
+					log.Debug().Err(err).Msg("runtime version subscription stopped")
+				}
+				return
+			case version, ok := <-sub.Chan():
+				if !ok {
+					return
+				}
+
+				if _, err := c.get(cl, genesis, version.SpecVersion, nil); err != nil {
+					log.Error().Err(err).Msg("failed to update metadata after runtime upgrade")
+				}
+			}
+		}
+	}, nil
+}
+
+// metadataAt returns the metadata valid at the given block and its runtime spec version
+func (s *Substrate) metadataAt(cl Conn, block types.Hash) (Meta, uint32, error) {
+	version, err := cl.RPC.State.GetRuntimeVersion(block)
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "failed to get runtime version at block '%s'", block.Hex())
+	}
+
+	meta, err := s.cache.get(cl, s.genesis, version.SpecVersion, &block)
+	return meta, uint32(version.SpecVersion), err
+}
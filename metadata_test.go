@@ -0,0 +1,78 @@
+package substrate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadataCachedAcrossConnections(t *testing.T) {
+	node := newFakeNode(t)
+
+	mgr := NewManager(node.URL())
+	defer closeManager(t, mgr)
+
+	// keep all clients open so every one of them needs a new connection
+	var clients []*Substrate
+	for i := 0; i < 3; i++ {
+		cl, err := mgr.Substrate()
+		require.NoError(t, err)
+		clients = append(clients, cl)
+	}
+
+	for _, cl := range clients {
+		cl.Close()
+	}
+
+	require.Equal(t, 3, node.dials())
+	require.Equal(t, 1, node.count("state_getMetadata"))
+}
+
+func TestMetadataRuntimeUpgrade(t *testing.T) {
+	node := newFakeNode(t)
+
+	cl := testClient(t, node, DefaultManagerOptions())
+
+	_, before, err := cl.GetClient()
+	require.NoError(t, err)
+
+	// wait for the runtime version subscription to be established
+	require.Eventually(t, func() bool {
+		return node.count("state_subscribeRuntimeVersion") == 1
+	}, time.Second, 10*time.Millisecond)
+
+	node.setSpec(2)
+	require.Eventually(t, func() bool {
+		return node.count("state_getMetadata") == 2
+	}, time.Second, 10*time.Millisecond)
+
+	require.Eventually(t, func() bool {
+		_, after, err := cl.GetClient()
+		return err == nil && after != before
+	}, time.Second, 10*time.Millisecond)
+
+	// same runtime version again doesn't download metadata
+	node.setSpec(2)
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(t, 2, node.count("state_getMetadata"))
+}
+
+func TestMetadataCachedForBlockEvents(t *testing.T) {
+	node := newFakeNode(t)
+
+	key, err := types.CreateStorageKey(node.meta, "System", "Events", nil)
+	require.NoError(t, err)
+	// no events
+	node.setStorage(key, []byte{0})
+
+	cl := testClient(t, node, DefaultManagerOptions())
+
+	for i := uint32(1); i <= 3; i++ {
+		_, err := cl.GetEventsForBlock(i)
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, 1, node.count("state_getMetadata"))
+}
@@ -0,0 +1,42 @@
+package substrate
+
+import (
+	"time"
+)
+
+// Extrinsic statuses reported to Metrics.ObserveExtrinsic
+const (
+	ExtrinsicInBlock         = "in-block"
+	ExtrinsicFinalized       = "finalized"
+	ExtrinsicUsurped         = "usurped"
+	ExtrinsicDropped         = "dropped"
+	ExtrinsicInvalid         = "invalid"
+	ExtrinsicFinalityTimeout = "finality-timeout"
+	ExtrinsicTimeout         = "timeout"
+	ExtrinsicError           = "error"
+)
+
+// Metrics observes the activity of the client, it's set with
+// ManagerOptions.Metrics. Methods are called concurrently and must not
+// block. The prometheus sub package provides an implementation
+type Metrics interface {
+	// ObserveConnect is called after every connection attempt to an endpoint
+	ObserveConnect(endpoint string, err error)
+	// ObserveRPC is called after every rpc call, like state_getStorage,
+	// with the time it took
+	ObserveRPC(method string, duration time.Duration, err error)
+	// ObserveExtrinsic is called once for every submitted extrinsic with its
+	// last status and the time since it was submitted. With WaitInBlock
+	// the status is ExtrinsicInBlock once the extrinsic is included
+	ObserveExtrinsic(status string, duration time.Duration)
+	// ObserveModuleError is called for every module error returned by a
+	// call, pallet is empty for errors not returned by a pallet
+	ObserveModuleError(pallet string, name string)
+}
+
+type noopMetrics struct{}
+
+func (noopMetrics) ObserveConnect(string, error)            {}
+func (noopMetrics) ObserveRPC(string, time.Duration, error) {}
+func (noopMetrics) ObserveExtrinsic(string, time.Duration)  {}
+func (noopMetrics) ObserveModuleError(string, string)       {}
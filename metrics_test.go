@@ -0,0 +1,142 @@
+package substrate
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingMetrics struct {
+	m            sync.Mutex
+	connects     []error
+	rpcs         map[string]int
+	extrinsics   []string
+	moduleErrors []string
+}
+
+func (r *recordingMetrics) ObserveConnect(endpoint string, err error) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.connects = append(r.connects, err)
+}
+
+func (r *recordingMetrics) ObserveRPC(method string, duration time.Duration, err error) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	if r.rpcs == nil {
+		r.rpcs = make(map[string]int)
+	}
+	r.rpcs[method]++
+}
+
+func (r *recordingMetrics) ObserveExtrinsic(status string, duration time.Duration) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.extrinsics = append(r.extrinsics, status)
+}
+
+func (r *recordingMetrics) ObserveModuleError(pallet string, name string) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.moduleErrors = append(r.moduleErrors, pallet+"."+name)
+}
+
+func metricsTestClient(t *testing.T, node *fakeNode) (*Substrate, *recordingMetrics) {
+	metrics := &recordingMetrics{}
+
+	opts := DefaultManagerOptions()
+	opts.Metrics = metrics
+	return testClient(t, node, opts), metrics
+}
+
+func TestMetricsConnectAndRPC(t *testing.T) {
+	node := newFakeNode(t)
+	sub, metrics := metricsTestClient(t, node)
+
+	_, err := sub.Time()
+	require.NoError(t, err)
+
+	metrics.m.Lock()
+	defer metrics.m.Unlock()
+	require.Equal(t, []error{nil}, metrics.connects)
+	require.NotZero(t, metrics.rpcs["state_getStorage"])
+	require.NotZero(t, metrics.rpcs["state_getRuntimeVersion"])
+}
+
+func TestMetricsExtrinsic(t *testing.T) {
+	node := newCallTestNode(t,
+		"ready",
+		map[string]interface{}{"usurped": types.NewHash([]byte{1}).Hex()},
+	)
+	sub, metrics := metricsTestClient(t, node)
+
+	identity, err := NewIdentityFromSr25519Phrase("//Alice")
+	require.NoError(t, err)
+	cl, meta, err := sub.GetClient()
+	require.NoError(t, err)
+
+	_, err = sub.CallOnce(cl, meta, identity, types.Call{})
+	require.ErrorIs(t, err, ErrIsUsurped)
+
+	metrics.m.Lock()
+	defer metrics.m.Unlock()
+	require.Equal(t, []string{ExtrinsicUsurped}, metrics.extrinsics)
+	require.Empty(t, metrics.moduleErrors)
+}
+
+func TestMetricsModuleError(t *testing.T) {
+	block := types.NewHash([]byte{1})
+	node := newCallTestNode(t,
+		map[string]interface{}{"inBlock": block.Hex()},
+	)
+
+	// include the submitted extrinsic in the block
+	node.handle("chain_getBlock", func(params []json.RawMessage) (interface{}, error) {
+		var ext string
+		if err := json.Unmarshal(node.lastParams("author_submitAndWatchExtrinsic")[0], &ext); err != nil {
+			return nil, err
+		}
+
+		return map[string]interface{}{
+			"block": map[string]interface{}{
+				"header": map[string]interface{}{
+					"parentHash":     types.Hash{}.Hex(),
+					"number":         "0x1",
+					"stateRoot":      types.Hash{}.Hex(),
+					"extrinsicsRoot": types.Hash{}.Hex(),
+					"digest":         map[string]interface{}{"logs": []string{}},
+				},
+				"extrinsics": []string{ext},
+			},
+		}, nil
+	})
+
+	key, err := types.CreateStorageKey(node.meta, "System", "Events", nil)
+	require.NoError(t, err)
+
+	// System.ExtrinsicFailed with Democracy.ValueLow
+	events := []byte{1 << 2}
+	events = append(events, 0, 0, 0, 0, 0)
+	events = append(events, 0, 1, 3, 12, 0, 0, 0, 0, 0)
+	events = append(events, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0)
+	node.setStorage(key, events)
+
+	sub, metrics := metricsTestClient(t, node)
+
+	identity, err := NewIdentityFromSr25519Phrase("//Alice")
+	require.NoError(t, err)
+	cl, meta, err := sub.GetClient()
+	require.NoError(t, err)
+
+	_, err = sub.Call(cl, meta, identity, types.Call{})
+	require.EqualError(t, err, "ValueLow")
+
+	metrics.m.Lock()
+	defer metrics.m.Unlock()
+	require.Equal(t, []string{ExtrinsicInBlock}, metrics.extrinsics)
+	require.Equal(t, []string{"Democracy.ValueLow"}, metrics.moduleErrors)
+}
@@ -1,6 +1,7 @@
 package substrate
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 
@@ -20,7 +21,12 @@ type MintTransaction struct {
 }
 
 func (s *Substrate) IsMintedAlready(mintTxID string) (exists bool, err error) {
-	cl, meta, err := s.GetClient()
+	return s.IsMintedAlreadyCtx(context.Background(), mintTxID)
+}
+
+// IsMintedAlreadyCtx is like IsMintedAlready but takes a context
+func (s *Substrate) IsMintedAlreadyCtx(ctx context.Context, mintTxID string) (exists bool, err error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return false, err
 	}
@@ -37,7 +43,7 @@ func (s *Substrate) IsMintedAlready(mintTxID string) (exists bool, err error) {
 		return
 	}
 
-	ok, err := cl.RPC.State.GetStorageLatest(key, &mintTX)
+	ok, err := s.getStorage(cl, key, &mintTX)
 	if err != nil {
 		return false, err
 	}
@@ -49,8 +55,13 @@ func (s *Substrate) IsMintedAlready(mintTxID string) (exists bool, err error) {
 	return true, nil
 }
 
-func (s *Substrate) ProposeOrVoteMintTransaction(identity Identity, txID string, target AccountID, amount *big.Int) error {
-	cl, meta, err := s.GetClient()
+func (s *Substrate) ProposeOrVoteMintTransaction(identity Identity, txID string, target AccountID, amount *big.Int, opts ...CallOption) error {
+	return s.ProposeOrVoteMintTransactionCtx(context.Background(), identity, txID, target, amount, opts...)
+}
+
+// ProposeOrVoteMintTransactionCtx is like ProposeOrVoteMintTransaction but takes a context
+func (s *Substrate) ProposeOrVoteMintTransactionCtx(ctx context.Context, identity Identity, txID string, target AccountID, amount *big.Int, opts ...CallOption) error {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return err
 	}
@@ -63,7 +74,7 @@ func (s *Substrate) ProposeOrVoteMintTransaction(identity Identity, txID string,
 		return errors.Wrap(err, "failed to create call")
 	}
 
-	_, err = s.Call(cl, meta, identity, c)
+	_, err = s.CallCtx(ctx, cl, meta, identity, c, opts...)
 	if err != nil {
 		return errors.Wrap(err, "failed to propose mint transaction")
 	}
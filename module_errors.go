@@ -0,0 +1,190 @@
+package substrate
+
+// Known module errors of the tfchain pallets. Errors are matched by name in
+// any pallet, so they can be used with errors.Is like
+//
+//	errors.Is(err, ErrNodeHasActiveContracts)
+var (
+	// TfgridModule errors
+	ErrNoneValue                          = &ModuleError{Kind: DispatchModule, Name: "NoneValue"}
+	ErrStorageOverflow                    = &ModuleError{Kind: DispatchModule, Name: "StorageOverflow"}
+	ErrCannotCreateNode                   = &ModuleError{Kind: DispatchModule, Name: "CannotCreateNode"}
+	ErrNodeNotExists                      = &ModuleError{Kind: DispatchModule, Name: "NodeNotExists"}
+	ErrNodeWithTwinIdExists               = &ModuleError{Kind: DispatchModule, Name: "NodeWithTwinIdExists"}
+	ErrCannotDeleteNode                   = &ModuleError{Kind: DispatchModule, Name: "CannotDeleteNode"}
+	ErrNodeDeleteNotAuthorized            = &ModuleError{Kind: DispatchModule, Name: "NodeDeleteNotAuthorized"}
+	ErrNodeUpdateNotAuthorized            = &ModuleError{Kind: DispatchModule, Name: "NodeUpdateNotAuthorized"}
+	ErrFarmExists                         = &ModuleError{Kind: DispatchModule, Name: "FarmExists"}
+	ErrFarmNotExists                      = &ModuleError{Kind: DispatchModule, Name: "FarmNotExists"}
+	ErrCannotCreateFarmWrongTwin          = &ModuleError{Kind: DispatchModule, Name: "CannotCreateFarmWrongTwin"}
+	ErrCannotUpdateFarmWrongTwin          = &ModuleError{Kind: DispatchModule, Name: "CannotUpdateFarmWrongTwin"}
+	ErrCannotDeleteFarm                   = &ModuleError{Kind: DispatchModule, Name: "CannotDeleteFarm"}
+	ErrCannotDeleteFarmWithPublicIPs      = &ModuleError{Kind: DispatchModule, Name: "CannotDeleteFarmWithPublicIPs"}
+	ErrCannotDeleteFarmWithNodesAssigned  = &ModuleError{Kind: DispatchModule, Name: "CannotDeleteFarmWithNodesAssigned"}
+	ErrCannotDeleteFarmWrongTwin          = &ModuleError{Kind: DispatchModule, Name: "CannotDeleteFarmWrongTwin"}
+	ErrIpExists                           = &ModuleError{Kind: DispatchModule, Name: "IpExists"}
+	ErrIpNotExists                        = &ModuleError{Kind: DispatchModule, Name: "IpNotExists"}
+	ErrEntityWithNameExists               = &ModuleError{Kind: DispatchModule, Name: "EntityWithNameExists"}
+	ErrEntityWithPubkeyExists             = &ModuleError{Kind: DispatchModule, Name: "EntityWithPubkeyExists"}
+	ErrEntityNotExists                    = &ModuleError{Kind: DispatchModule, Name: "EntityNotExists"}
+	ErrEntitySignatureDoesNotMatch        = &ModuleError{Kind: DispatchModule, Name: "EntitySignatureDoesNotMatch"}
+	ErrEntityWithSignatureAlreadyExists   = &ModuleError{Kind: DispatchModule, Name: "EntityWithSignatureAlreadyExists"}
+	ErrCannotUpdateEntity                 = &ModuleError{Kind: DispatchModule, Name: "CannotUpdateEntity"}
+	ErrCannotDeleteEntity                 = &ModuleError{Kind: DispatchModule, Name: "CannotDeleteEntity"}
+	ErrSignatureLengthIsIncorrect         = &ModuleError{Kind: DispatchModule, Name: "SignatureLengthIsIncorrect"}
+	ErrTwinExists                         = &ModuleError{Kind: DispatchModule, Name: "TwinExists"}
+	ErrTwinNotExists                      = &ModuleError{Kind: DispatchModule, Name: "TwinNotExists"}
+	ErrTwinWithPubkeyExists               = &ModuleError{Kind: DispatchModule, Name: "TwinWithPubkeyExists"}
+	ErrCannotCreateTwin                   = &ModuleError{Kind: DispatchModule, Name: "CannotCreateTwin"}
+	ErrUnauthorizedToUpdateTwin           = &ModuleError{Kind: DispatchModule, Name: "UnauthorizedToUpdateTwin"}
+	ErrPricingPolicyExists                = &ModuleError{Kind: DispatchModule, Name: "PricingPolicyExists"}
+	ErrPricingPolicyNotExists             = &ModuleError{Kind: DispatchModule, Name: "PricingPolicyNotExists"}
+	ErrPricingPolicyWithDifferentIdExists = &ModuleError{Kind: DispatchModule, Name: "PricingPolicyWithDifferentIdExists"}
+	ErrCertificationCodeExists            = &ModuleError{Kind: DispatchModule, Name: "CertificationCodeExists"}
+	ErrFarmingPolicyAlreadyExists         = &ModuleError{Kind: DispatchModule, Name: "FarmingPolicyAlreadyExists"}
+	ErrFarmPayoutAdressAlreadyRegistered  = &ModuleError{Kind: DispatchModule, Name: "FarmPayoutAdressAlreadyRegistered"}
+	ErrFarmerDoesNotHaveEnoughFunds       = &ModuleError{Kind: DispatchModule, Name: "FarmerDoesNotHaveEnoughFunds"}
+	ErrUserDidNotSignTermsAndConditions   = &ModuleError{Kind: DispatchModule, Name: "UserDidNotSignTermsAndConditions"}
+	ErrFarmerDidNotSignTermsAndConditions = &ModuleError{Kind: DispatchModule, Name: "FarmerDidNotSignTermsAndConditions"}
+	ErrFarmerNotAuthorized                = &ModuleError{Kind: DispatchModule, Name: "FarmerNotAuthorized"}
+	ErrInvalidFarmName                    = &ModuleError{Kind: DispatchModule, Name: "InvalidFarmName"}
+	ErrAlreadyCertifier                   = &ModuleError{Kind: DispatchModule, Name: "AlreadyCertifier"}
+	ErrNotCertifier                       = &ModuleError{Kind: DispatchModule, Name: "NotCertifier"}
+	ErrNotAllowedToCertifyNode            = &ModuleError{Kind: DispatchModule, Name: "NotAllowedToCertifyNode"}
+	ErrFarmingPolicyNotExists             = &ModuleError{Kind: DispatchModule, Name: "FarmingPolicyNotExists"}
+	ErrRelayTooShort                      = &ModuleError{Kind: DispatchModule, Name: "RelayTooShort"}
+	ErrRelayTooLong                       = &ModuleError{Kind: DispatchModule, Name: "RelayTooLong"}
+	ErrInvalidRelay                       = &ModuleError{Kind: DispatchModule, Name: "InvalidRelay"}
+	ErrFarmNameTooShort                   = &ModuleError{Kind: DispatchModule, Name: "FarmNameTooShort"}
+	ErrFarmNameTooLong                    = &ModuleError{Kind: DispatchModule, Name: "FarmNameTooLong"}
+	ErrInvalidPublicIP                    = &ModuleError{Kind: DispatchModule, Name: "InvalidPublicIP"}
+	ErrPublicIPTooShort                   = &ModuleError{Kind: DispatchModule, Name: "PublicIPTooShort"}
+	ErrPublicIPTooLong                    = &ModuleError{Kind: DispatchModule, Name: "PublicIPTooLong"}
+	ErrGatewayIPTooShort                  = &ModuleError{Kind: DispatchModule, Name: "GatewayIPTooShort"}
+	ErrGatewayIPTooLong                   = &ModuleError{Kind: DispatchModule, Name: "GatewayIPTooLong"}
+	ErrIP4TooShort                        = &ModuleError{Kind: DispatchModule, Name: "IP4TooShort"}
+	ErrIP4TooLong                         = &ModuleError{Kind: DispatchModule, Name: "IP4TooLong"}
+	ErrInvalidIP4                         = &ModuleError{Kind: DispatchModule, Name: "InvalidIP4"}
+	ErrGW4TooShort                        = &ModuleError{Kind: DispatchModule, Name: "GW4TooShort"}
+	ErrGW4TooLong                         = &ModuleError{Kind: DispatchModule, Name: "GW4TooLong"}
+	ErrInvalidGW4                         = &ModuleError{Kind: DispatchModule, Name: "InvalidGW4"}
+	ErrIP6TooShort                        = &ModuleError{Kind: DispatchModule, Name: "IP6TooShort"}
+	ErrIP6TooLong                         = &ModuleError{Kind: DispatchModule, Name: "IP6TooLong"}
+	ErrInvalidIP6                         = &ModuleError{Kind: DispatchModule, Name: "InvalidIP6"}
+	ErrGW6TooShort                        = &ModuleError{Kind: DispatchModule, Name: "GW6TooShort"}
+	ErrGW6TooLong                         = &ModuleError{Kind: DispatchModule, Name: "GW6TooLong"}
+	ErrInvalidGW6                         = &ModuleError{Kind: DispatchModule, Name: "InvalidGW6"}
+	ErrDomainTooShort                     = &ModuleError{Kind: DispatchModule, Name: "DomainTooShort"}
+	ErrDomainTooLong                      = &ModuleError{Kind: DispatchModule, Name: "DomainTooLong"}
+	ErrInvalidDomain                      = &ModuleError{Kind: DispatchModule, Name: "InvalidDomain"}
+	ErrMethodIsDeprecated                 = &ModuleError{Kind: DispatchModule, Name: "MethodIsDeprecated"}
+	ErrInterfaceNameTooShort              = &ModuleError{Kind: DispatchModule, Name: "InterfaceNameTooShort"}
+	ErrInterfaceNameTooLong               = &ModuleError{Kind: DispatchModule, Name: "InterfaceNameTooLong"}
+	ErrInvalidInterfaceName               = &ModuleError{Kind: DispatchModule, Name: "InvalidInterfaceName"}
+	ErrInterfaceMacTooShort               = &ModuleError{Kind: DispatchModule, Name: "InterfaceMacTooShort"}
+	ErrInterfaceMacTooLong                = &ModuleError{Kind: DispatchModule, Name: "InterfaceMacTooLong"}
+	ErrInvalidMacAddress                  = &ModuleError{Kind: DispatchModule, Name: "InvalidMacAddress"}
+	ErrInterfaceIpTooShort                = &ModuleError{Kind: DispatchModule, Name: "InterfaceIpTooShort"}
+	ErrInterfaceIpTooLong                 = &ModuleError{Kind: DispatchModule, Name: "InterfaceIpTooLong"}
+	ErrInvalidInterfaceIP                 = &ModuleError{Kind: DispatchModule, Name: "InvalidInterfaceIP"}
+	ErrInvalidZosVersion                  = &ModuleError{Kind: DispatchModule, Name: "InvalidZosVersion"}
+	ErrFarmingPolicyExpired               = &ModuleError{Kind: DispatchModule, Name: "FarmingPolicyExpired"}
+	ErrInvalidHRUInput                    = &ModuleError{Kind: DispatchModule, Name: "InvalidHRUInput"}
+	ErrInvalidSRUInput                    = &ModuleError{Kind: DispatchModule, Name: "InvalidSRUInput"}
+	ErrInvalidCRUInput                    = &ModuleError{Kind: DispatchModule, Name: "InvalidCRUInput"}
+	ErrInvalidMRUInput                    = &ModuleError{Kind: DispatchModule, Name: "InvalidMRUInput"}
+	ErrLatitudeInputTooShort              = &ModuleError{Kind: DispatchModule, Name: "LatitudeInputTooShort"}
+	ErrLatitudeInputTooLong               = &ModuleError{Kind: DispatchModule, Name: "LatitudeInputTooLong"}
+	ErrInvalidLatitudeInput               = &ModuleError{Kind: DispatchModule, Name: "InvalidLatitudeInput"}
+	ErrLongitudeInputTooShort             = &ModuleError{Kind: DispatchModule, Name: "LongitudeInputTooShort"}
+	ErrLongitudeInputTooLong              = &ModuleError{Kind: DispatchModule, Name: "LongitudeInputTooLong"}
+	ErrInvalidLongitudeInput              = &ModuleError{Kind: DispatchModule, Name: "InvalidLongitudeInput"}
+	ErrCountryNameTooShort                = &ModuleError{Kind: DispatchModule, Name: "CountryNameTooShort"}
+	ErrCountryNameTooLong                 = &ModuleError{Kind: DispatchModule, Name: "CountryNameTooLong"}
+	ErrInvalidCountryName                 = &ModuleError{Kind: DispatchModule, Name: "InvalidCountryName"}
+	ErrCityNameTooShort                   = &ModuleError{Kind: DispatchModule, Name: "CityNameTooShort"}
+	ErrCityNameTooLong                    = &ModuleError{Kind: DispatchModule, Name: "CityNameTooLong"}
+	ErrInvalidCityName                    = &ModuleError{Kind: DispatchModule, Name: "InvalidCityName"}
+	ErrInvalidCountryCityPair             = &ModuleError{Kind: DispatchModule, Name: "InvalidCountryCityPair"}
+	ErrSerialNumberTooShort               = &ModuleError{Kind: DispatchModule, Name: "SerialNumberTooShort"}
+	ErrSerialNumberTooLong                = &ModuleError{Kind: DispatchModule, Name: "SerialNumberTooLong"}
+	ErrInvalidSerialNumber                = &ModuleError{Kind: DispatchModule, Name: "InvalidSerialNumber"}
+	ErrDocumentLinkInputTooShort          = &ModuleError{Kind: DispatchModule, Name: "DocumentLinkInputTooShort"}
+	ErrDocumentLinkInputTooLong           = &ModuleError{Kind: DispatchModule, Name: "DocumentLinkInputTooLong"}
+	ErrInvalidDocumentLinkInput           = &ModuleError{Kind: DispatchModule, Name: "InvalidDocumentLinkInput"}
+	ErrDocumentHashInputTooShort          = &ModuleError{Kind: DispatchModule, Name: "DocumentHashInputTooShort"}
+	ErrDocumentHashInputTooLong           = &ModuleError{Kind: DispatchModule, Name: "DocumentHashInputTooLong"}
+	ErrInvalidDocumentHashInput           = &ModuleError{Kind: DispatchModule, Name: "InvalidDocumentHashInput"}
+	ErrInvalidPublicConfig                = &ModuleError{Kind: DispatchModule, Name: "InvalidPublicConfig"}
+	ErrUnauthorizedToChangePowerTarget    = &ModuleError{Kind: DispatchModule, Name: "UnauthorizedToChangePowerTarget"}
+	ErrInvalidRelayAddress                = &ModuleError{Kind: DispatchModule, Name: "InvalidRelayAddress"}
+
+	// SmartContractModule errors
+	ErrFarmHasNotEnoughPublicIPs                   = &ModuleError{Kind: DispatchModule, Name: "FarmHasNotEnoughPublicIPs"}
+	ErrFarmHasNotEnoughPublicIPsFree               = &ModuleError{Kind: DispatchModule, Name: "FarmHasNotEnoughPublicIPsFree"}
+	ErrFailedToReserveIP                           = &ModuleError{Kind: DispatchModule, Name: "FailedToReserveIP"}
+	ErrFailedToFreeIPs                             = &ModuleError{Kind: DispatchModule, Name: "FailedToFreeIPs"}
+	ErrContractNotExists                           = &ModuleError{Kind: DispatchModule, Name: "ContractNotExists"}
+	ErrTwinNotAuthorizedToUpdateContract           = &ModuleError{Kind: DispatchModule, Name: "TwinNotAuthorizedToUpdateContract"}
+	ErrTwinNotAuthorizedToCancelContract           = &ModuleError{Kind: DispatchModule, Name: "TwinNotAuthorizedToCancelContract"}
+	ErrNodeNotAuthorizedToDeployContract           = &ModuleError{Kind: DispatchModule, Name: "NodeNotAuthorizedToDeployContract"}
+	ErrNodeNotAuthorizedToComputeReport            = &ModuleError{Kind: DispatchModule, Name: "NodeNotAuthorizedToComputeReport"}
+	ErrContractIsNotUnique                         = &ModuleError{Kind: DispatchModule, Name: "ContractIsNotUnique"}
+	ErrNameExists                                  = &ModuleError{Kind: DispatchModule, Name: "NameExists"}
+	ErrNameNotValid                                = &ModuleError{Kind: DispatchModule, Name: "NameNotValid"}
+	ErrInvalidContractType                         = &ModuleError{Kind: DispatchModule, Name: "InvalidContractType"}
+	ErrTFTPriceValueError                          = &ModuleError{Kind: DispatchModule, Name: "TFTPriceValueError"}
+	ErrNotEnoughResourcesOnNode                    = &ModuleError{Kind: DispatchModule, Name: "NotEnoughResourcesOnNode"}
+	ErrNodeNotAuthorizedToReportResources          = &ModuleError{Kind: DispatchModule, Name: "NodeNotAuthorizedToReportResources"}
+	ErrNodeHasActiveContracts                      = &ModuleError{Kind: DispatchModule, Name: "NodeHasActiveContracts"}
+	ErrNodeHasRentContract                         = &ModuleError{Kind: DispatchModule, Name: "NodeHasRentContract"}
+	ErrNodeIsNotDedicated                          = &ModuleError{Kind: DispatchModule, Name: "NodeIsNotDedicated"}
+	ErrNodeNotAvailableToDeploy                    = &ModuleError{Kind: DispatchModule, Name: "NodeNotAvailableToDeploy"}
+	ErrCannotUpdateContractInGraceState            = &ModuleError{Kind: DispatchModule, Name: "CannotUpdateContractInGraceState"}
+	ErrNumOverflow                                 = &ModuleError{Kind: DispatchModule, Name: "NumOverflow"}
+	ErrOffchainSignedTxCannotSign                  = &ModuleError{Kind: DispatchModule, Name: "OffchainSignedTxCannotSign"}
+	ErrOffchainSignedTxAlreadySent                 = &ModuleError{Kind: DispatchModule, Name: "OffchainSignedTxAlreadySent"}
+	ErrOffchainSignedTxNoLocalAccountAvailable     = &ModuleError{Kind: DispatchModule, Name: "OffchainSignedTxNoLocalAccountAvailable"}
+	ErrNameContractNameTooShort                    = &ModuleError{Kind: DispatchModule, Name: "NameContractNameTooShort"}
+	ErrNameContractNameTooLong                     = &ModuleError{Kind: DispatchModule, Name: "NameContractNameTooLong"}
+	ErrInvalidProviderConfiguration                = &ModuleError{Kind: DispatchModule, Name: "InvalidProviderConfiguration"}
+	ErrNoSuchSolutionProvider                      = &ModuleError{Kind: DispatchModule, Name: "NoSuchSolutionProvider"}
+	ErrSolutionProviderNotApproved                 = &ModuleError{Kind: DispatchModule, Name: "SolutionProviderNotApproved"}
+	ErrTwinNotAuthorized                           = &ModuleError{Kind: DispatchModule, Name: "TwinNotAuthorized"}
+	ErrServiceContractNotExists                    = &ModuleError{Kind: DispatchModule, Name: "ServiceContractNotExists"}
+	ErrServiceContractCreationNotAllowed           = &ModuleError{Kind: DispatchModule, Name: "ServiceContractCreationNotAllowed"}
+	ErrServiceContractModificationNotAllowed       = &ModuleError{Kind: DispatchModule, Name: "ServiceContractModificationNotAllowed"}
+	ErrServiceContractApprovalNotAllowed           = &ModuleError{Kind: DispatchModule, Name: "ServiceContractApprovalNotAllowed"}
+	ErrServiceContractRejectionNotAllowed          = &ModuleError{Kind: DispatchModule, Name: "ServiceContractRejectionNotAllowed"}
+	ErrServiceContractBillingNotApprovedByBoth     = &ModuleError{Kind: DispatchModule, Name: "ServiceContractBillingNotApprovedByBoth"}
+	ErrServiceContractBillingVariableAmountTooHigh = &ModuleError{Kind: DispatchModule, Name: "ServiceContractBillingVariableAmountTooHigh"}
+	ErrServiceContractBillMetadataTooLong          = &ModuleError{Kind: DispatchModule, Name: "ServiceContractBillMetadataTooLong"}
+	ErrServiceContractMetadataTooLong              = &ModuleError{Kind: DispatchModule, Name: "ServiceContractMetadataTooLong"}
+	ErrServiceContractNotEnoughFundsToPayBill      = &ModuleError{Kind: DispatchModule, Name: "ServiceContractNotEnoughFundsToPayBill"}
+	ErrCanOnlyIncreaseFrequency                    = &ModuleError{Kind: DispatchModule, Name: "CanOnlyIncreaseFrequency"}
+	ErrIsNotAnAuthority                            = &ModuleError{Kind: DispatchModule, Name: "IsNotAnAuthority"}
+	ErrWrongAuthority                              = &ModuleError{Kind: DispatchModule, Name: "WrongAuthority"}
+
+	// TFTBridgeModule errors
+	ErrValidatorExists                  = &ModuleError{Kind: DispatchModule, Name: "ValidatorExists"}
+	ErrValidatorNotExists               = &ModuleError{Kind: DispatchModule, Name: "ValidatorNotExists"}
+	ErrTransactionValidatorExists       = &ModuleError{Kind: DispatchModule, Name: "TransactionValidatorExists"}
+	ErrTransactionValidatorNotExists    = &ModuleError{Kind: DispatchModule, Name: "TransactionValidatorNotExists"}
+	ErrMintTransactionExists            = &ModuleError{Kind: DispatchModule, Name: "MintTransactionExists"}
+	ErrMintTransactionAlreadyExecuted   = &ModuleError{Kind: DispatchModule, Name: "MintTransactionAlreadyExecuted"}
+	ErrMintTransactionNotExists         = &ModuleError{Kind: DispatchModule, Name: "MintTransactionNotExists"}
+	ErrBurnTransactionExists            = &ModuleError{Kind: DispatchModule, Name: "BurnTransactionExists"}
+	ErrBurnTransactionNotExists         = &ModuleError{Kind: DispatchModule, Name: "BurnTransactionNotExists"}
+	ErrBurnSignatureExists              = &ModuleError{Kind: DispatchModule, Name: "BurnSignatureExists"}
+	ErrEnoughBurnSignaturesPresent      = &ModuleError{Kind: DispatchModule, Name: "EnoughBurnSignaturesPresent"}
+	ErrRefundSignatureExists            = &ModuleError{Kind: DispatchModule, Name: "RefundSignatureExists"}
+	ErrBurnTransactionAlreadyExecuted   = &ModuleError{Kind: DispatchModule, Name: "BurnTransactionAlreadyExecuted"}
+	ErrRefundTransactionNotExists       = &ModuleError{Kind: DispatchModule, Name: "RefundTransactionNotExists"}
+	ErrRefundTransactionAlreadyExecuted = &ModuleError{Kind: DispatchModule, Name: "RefundTransactionAlreadyExecuted"}
+	ErrEnoughRefundSignaturesPresent    = &ModuleError{Kind: DispatchModule, Name: "EnoughRefundSignaturesPresent"}
+	ErrNotEnoughBalanceToSwap           = &ModuleError{Kind: DispatchModule, Name: "NotEnoughBalanceToSwap"}
+	ErrAmountIsLessThanWithdrawFee      = &ModuleError{Kind: DispatchModule, Name: "AmountIsLessThanWithdrawFee"}
+	ErrAmountIsLessThanDepositFee       = &ModuleError{Kind: DispatchModule, Name: "AmountIsLessThanDepositFee"}
+	ErrWrongParametersProvided          = &ModuleError{Kind: DispatchModule, Name: "WrongParametersProvided"}
+	ErrInvalidStellarPublicKey          = &ModuleError{Kind: DispatchModule, Name: "InvalidStellarPublicKey"}
+)
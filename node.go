@@ -368,7 +368,12 @@ type NodeExtra struct {
 
 // GetNodeByTwinID gets a node by twin id
 func (s *Substrate) GetNodeByTwinID(twin uint32) (uint32, error) {
-	cl, meta, err := s.GetClient()
+	return s.GetNodeByTwinIDCtx(context.Background(), twin)
+}
+
+// GetNodeByTwinIDCtx is like GetNodeByTwinID but takes a context
+func (s *Substrate) GetNodeByTwinIDCtx(ctx context.Context, twin uint32) (uint32, error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -381,7 +386,7 @@ func (s *Substrate) GetNodeByTwinID(twin uint32) (uint32, error) {
 		return 0, errors.Wrap(err, "failed to create substrate query key")
 	}
 	var id types.U32
-	ok, err := cl.RPC.State.GetStorageLatest(key, &id)
+	ok, err := s.getStorage(cl, key, &id)
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to lookup entity")
 	}
@@ -395,7 +400,12 @@ func (s *Substrate) GetNodeByTwinID(twin uint32) (uint32, error) {
 
 // GetNode with id
 func (s *Substrate) GetNode(id uint32) (*Node, error) {
-	cl, meta, err := s.GetClient()
+	return s.GetNodeCtx(context.Background(), id)
+}
+
+// GetNodeCtx is like GetNode but takes a context
+func (s *Substrate) GetNodeCtx(ctx context.Context, id uint32) (*Node, error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -418,38 +428,27 @@ type ScannedNode struct {
 	Err  error
 }
 
-func (s *Substrate) ScanNodes(ctx context.Context, from, to uint32) (<-chan ScannedNode, error) {
-	cl, meta, err := s.GetClient()
+// ScanNodes gets the nodes with IDs from-to, sent in order of their IDs. Missing
+// nodes are sent with an ErrNotFound error. The nodes of ChunkSize IDs are queried
+// in a single rpc call, and Concurrency chunks are queried in parallel.
+func (s *Substrate) ScanNodes(ctx context.Context, from, to uint32, opts ...RangeOption) (<-chan ScannedNode, error) {
+	entries, err := s.scan(ctx, "TfgridModule", "Nodes", uint64(from), uint64(to), encodeU32, opts)
 	if err != nil {
 		return nil, err
 	}
-	ch := make(chan ScannedNode)
-
-	getNode := func(id uint32) (*Node, error) {
-		bytes, err := types.Encode(id)
-		if err != nil {
-			return nil, errors.Wrap(err, "substrate: encoding error building query arguments")
-		}
-
-		key, err := types.CreateStorageKey(meta, "TfgridModule", "Nodes", bytes, nil)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to create substrate query key")
-		}
 
-		return s.getNode(cl, key)
-	}
-
-	go func(from, to uint32) {
+	ch := make(chan ScannedNode)
+	go func() {
 		defer close(ch)
 
-		for ; from <= to; from++ {
-			var scanned ScannedNode
-			scanned.ID = from
-			node, err := getNode(from)
-			if err != nil {
-				scanned.Err = err
-			} else {
-				scanned.Node = *node
+		for entry := range entries {
+			scanned := ScannedNode{ID: uint32(entry.id), Err: entry.err}
+			if errors.Is(scanned.Err, ErrNotFound) {
+				scanned.Err = errors.Wrap(ErrNotFound, "node not found")
+			} else if scanned.Err == nil {
+				if err := types.Decode(entry.value, &scanned.Node); err != nil {
+					scanned.Err = errors.Wrap(err, "failed to load object")
+				}
 			}
 
 			select {
@@ -458,15 +457,19 @@ func (s *Substrate) ScanNodes(ctx context.Context, from, to uint32) (<-chan Scan
 			case ch <- scanned:
 			}
 		}
-
-	}(from, to)
+	}()
 
 	return ch, nil
 }
 
 // GetNodes gets nodes' IDs using farm id
 func (s *Substrate) GetNodes(farmID uint32) ([]uint32, error) {
-	cl, meta, err := s.GetClient()
+	return s.GetNodesCtx(context.Background(), farmID)
+}
+
+// GetNodesCtx is like GetNodes but takes a context
+func (s *Substrate) GetNodesCtx(ctx context.Context, farmID uint32) ([]uint32, error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return []uint32{}, err
 	}
@@ -481,7 +484,7 @@ func (s *Substrate) GetNodes(farmID uint32) ([]uint32, error) {
 		return []uint32{}, errors.Wrap(err, "failed to create substrate query key")
 	}
 
-	raw, err := cl.RPC.State.GetStorageRawLatest(key)
+	raw, err := s.getStorageRaw(cl, key)
 	if err != nil {
 		return []uint32{}, errors.Wrap(err, "failed to lookup entity")
 	}
@@ -500,7 +503,7 @@ func (s *Substrate) GetNodes(farmID uint32) ([]uint32, error) {
 }
 
 func (s *Substrate) getNode(cl Conn, key types.StorageKey) (*Node, error) {
-	raw, err := cl.RPC.State.GetStorageRawLatest(key)
+	raw, err := s.getStorageRaw(cl, key)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to lookup entity")
 	}
@@ -520,7 +523,12 @@ func (s *Substrate) getNode(cl Conn, key types.StorageKey) (*Node, error) {
 // CreateNode creates a node, this ignores public_config since
 // this is only setable by the farmer
 func (s *Substrate) CreateNode(identity Identity, node Node) (uint32, error) {
-	cl, meta, err := s.GetClient()
+	return s.CreateNodeCtx(context.Background(), identity, node)
+}
+
+// CreateNodeCtx is like CreateNode but takes a context
+func (s *Substrate) CreateNodeCtx(ctx context.Context, identity Identity, node Node) (uint32, error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -543,18 +551,23 @@ func (s *Substrate) CreateNode(identity Identity, node Node) (uint32, error) {
 		return 0, errors.Wrap(err, "failed to create call")
 	}
 
-	if _, err := s.Call(cl, meta, identity, c); err != nil {
+	if _, err := s.CallCtx(ctx, cl, meta, identity, c); err != nil {
 		return 0, errors.Wrap(err, "failed to create node")
 	}
 
-	return s.GetNodeByTwinID(uint32(node.TwinID))
+	return s.GetNodeByTwinIDCtx(ctx, uint32(node.TwinID))
 
 }
 
 // UpdateNode updates a node, this ignores public_config and only keep the value
 // set by the farmer
 func (s *Substrate) UpdateNode(identity Identity, node Node) (uint32, error) {
-	cl, meta, err := s.GetClient()
+	return s.UpdateNodeCtx(context.Background(), identity, node)
+}
+
+// UpdateNodeCtx is like UpdateNode but takes a context
+func (s *Substrate) UpdateNodeCtx(ctx context.Context, identity Identity, node Node) (uint32, error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -581,19 +594,24 @@ func (s *Substrate) UpdateNode(identity Identity, node Node) (uint32, error) {
 		return 0, errors.Wrap(err, "failed to create call")
 	}
 
-	callResponse, err := s.Call(cl, meta, identity, c)
+	callResponse, err := s.CallCtx(ctx, cl, meta, identity, c)
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to update node")
 	}
 
 	log.Debug().Str("hash", callResponse.Hash.Hex()).Msg("update call hash")
 
-	return s.GetNodeByTwinID(uint32(node.TwinID))
+	return s.GetNodeByTwinIDCtx(ctx, uint32(node.TwinID))
 }
 
 // UpdateNodeUptime updates the node uptime to given value
 func (s *Substrate) UpdateNodeUptime(identity Identity, uptime uint64) (hash types.Hash, err error) {
-	cl, meta, err := s.GetClient()
+	return s.UpdateNodeUptimeCtx(context.Background(), identity, uptime)
+}
+
+// UpdateNodeUptimeCtx is like UpdateNodeUptime but takes a context
+func (s *Substrate) UpdateNodeUptimeCtx(ctx context.Context, identity Identity, uptime uint64) (hash types.Hash, err error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return hash, err
 	}
@@ -604,7 +622,7 @@ func (s *Substrate) UpdateNodeUptime(identity Identity, uptime uint64) (hash typ
 		return hash, errors.Wrap(err, "failed to create call")
 	}
 
-	callResponse, err := s.Call(cl, meta, identity, c)
+	callResponse, err := s.CallCtx(ctx, cl, meta, identity, c)
 	if err != nil {
 		return hash, errors.Wrap(err, "failed to update node uptime")
 	}
@@ -612,9 +630,82 @@ func (s *Substrate) UpdateNodeUptime(identity Identity, uptime uint64) (hash typ
 	return callResponse.Hash, nil
 }
 
+// SetNodePublicConfig sets the public config of a node, only the
+// farmer of the node can set it
+func (s *Substrate) SetNodePublicConfig(identity Identity, farmID uint32, nodeID uint32, cfg PublicConfig) error {
+	return s.SetNodePublicConfigCtx(context.Background(), identity, farmID, nodeID, cfg)
+}
+
+// SetNodePublicConfigCtx is like SetNodePublicConfig but takes a context
+func (s *Substrate) SetNodePublicConfigCtx(ctx context.Context, identity Identity, farmID uint32, nodeID uint32, cfg PublicConfig) error {
+	if err := validatePublicConfig(cfg); err != nil {
+		return err
+	}
+
+	return s.nodePublicConfigCall(ctx, identity, farmID, nodeID, OptionPublicConfig{HasValue: true, AsValue: cfg})
+}
+
+// ClearNodePublicConfig removes the public config of a node
+func (s *Substrate) ClearNodePublicConfig(identity Identity, farmID uint32, nodeID uint32) error {
+	return s.ClearNodePublicConfigCtx(context.Background(), identity, farmID, nodeID)
+}
+
+// ClearNodePublicConfigCtx is like ClearNodePublicConfig but takes a context
+func (s *Substrate) ClearNodePublicConfigCtx(ctx context.Context, identity Identity, farmID uint32, nodeID uint32) error {
+	return s.nodePublicConfigCall(ctx, identity, farmID, nodeID, OptionPublicConfig{})
+}
+
+func (s *Substrate) nodePublicConfigCall(ctx context.Context, identity Identity, farmID uint32, nodeID uint32, cfg OptionPublicConfig) error {
+	cl, meta, err := s.getClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	c, err := types.NewCall(meta, "TfgridModule.add_node_public_config", farmID, nodeID, cfg)
+	if err != nil {
+		return errors.Wrap(err, "failed to create call")
+	}
+
+	if _, err := s.CallCtx(ctx, cl, meta, identity, c); err != nil {
+		return errors.Wrap(err, "failed to set node public config")
+	}
+
+	return nil
+}
+
+// DeleteNode deletes a node from its farm, only the farmer of
+// the node can delete it
+func (s *Substrate) DeleteNode(identity Identity, nodeID uint32) error {
+	return s.DeleteNodeCtx(context.Background(), identity, nodeID)
+}
+
+// DeleteNodeCtx is like DeleteNode but takes a context
+func (s *Substrate) DeleteNodeCtx(ctx context.Context, identity Identity, nodeID uint32) error {
+	cl, meta, err := s.getClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	c, err := types.NewCall(meta, "TfgridModule.delete_node_farm", nodeID)
+	if err != nil {
+		return errors.Wrap(err, "failed to create call")
+	}
+
+	if _, err := s.CallCtx(ctx, cl, meta, identity, c); err != nil {
+		return errors.Wrap(err, "failed to delete node")
+	}
+
+	return nil
+}
+
 // GetNode with id
 func (s *Substrate) GetLastNodeID() (uint32, error) {
-	cl, meta, err := s.GetClient()
+	return s.GetLastNodeIDCtx(context.Background())
+}
+
+// GetLastNodeIDCtx is like GetLastNodeID but takes a context
+func (s *Substrate) GetLastNodeIDCtx(ctx context.Context) (uint32, error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -624,7 +715,7 @@ func (s *Substrate) GetLastNodeID() (uint32, error) {
 		return 0, errors.Wrap(err, "failed to create substrate query key")
 	}
 
-	raw, err := cl.RPC.State.GetStorageRawLatest(key)
+	raw, err := s.getStorageRaw(cl, key)
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to lookup node id")
 	}
@@ -643,7 +734,12 @@ func (s *Substrate) GetLastNodeID() (uint32, error) {
 
 // SetNodeCertificate sets the node certificate type
 func (s *Substrate) SetNodeCertificate(sudo Identity, id uint32, cert NodeCertification) error {
-	cl, meta, err := s.GetClient()
+	return s.SetNodeCertificateCtx(context.Background(), sudo, id, cert)
+}
+
+// SetNodeCertificateCtx is like SetNodeCertificate but takes a context
+func (s *Substrate) SetNodeCertificateCtx(ctx context.Context, sudo Identity, id uint32, cert NodeCertification) error {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return err
 	}
@@ -660,7 +756,7 @@ func (s *Substrate) SetNodeCertificate(sudo Identity, id uint32, cert NodeCertif
 		return errors.Wrap(err, "failed to create sudo call")
 	}
 
-	if _, err := s.Call(cl, meta, sudo, su); err != nil {
+	if _, err := s.CallCtx(ctx, cl, meta, sudo, su); err != nil {
 		return errors.Wrap(err, "failed to set node certificate")
 	}
 
@@ -669,7 +765,12 @@ func (s *Substrate) SetNodeCertificate(sudo Identity, id uint32, cert NodeCertif
 
 // UpdateNodeUptime updates the node uptime to given value
 func (s *Substrate) SetNodePowerState(identity Identity, up bool) (hash types.Hash, err error) {
-	cl, meta, err := s.GetClient()
+	return s.SetNodePowerStateCtx(context.Background(), identity, up)
+}
+
+// SetNodePowerStateCtx is like SetNodePowerState but takes a context
+func (s *Substrate) SetNodePowerStateCtx(ctx context.Context, identity Identity, up bool) (hash types.Hash, err error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return hash, err
 	}
@@ -685,7 +786,7 @@ func (s *Substrate) SetNodePowerState(identity Identity, up bool) (hash types.Ha
 		return hash, errors.Wrap(err, "failed to create call")
 	}
 
-	callResponse, err := s.Call(cl, meta, identity, c)
+	callResponse, err := s.CallCtx(ctx, cl, meta, identity, c)
 	if err != nil {
 		return hash, errors.Wrap(err, "failed to update node power state")
 	}
@@ -694,7 +795,12 @@ func (s *Substrate) SetNodePowerState(identity Identity, up bool) (hash types.Ha
 }
 
 func (s *Substrate) GetPowerTarget(nodeID uint32) (power NodePower, err error) {
-	cl, meta, err := s.GetClient()
+	return s.GetPowerTargetCtx(context.Background(), nodeID)
+}
+
+// GetPowerTargetCtx is like GetPowerTarget but takes a context
+func (s *Substrate) GetPowerTargetCtx(ctx context.Context, nodeID uint32) (power NodePower, err error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return power, err
 	}
@@ -709,7 +815,7 @@ func (s *Substrate) GetPowerTarget(nodeID uint32) (power NodePower, err error) {
 		return power, errors.Wrap(err, "failed to create substrate query key")
 	}
 
-	raw, err := cl.RPC.State.GetStorageRawLatest(key)
+	raw, err := s.getStorageRaw(cl, key)
 	if err != nil {
 		return power, errors.Wrap(err, "failed to lookup power target")
 	}
@@ -0,0 +1,84 @@
+package substrate
+
+import (
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/pkg/errors"
+)
+
+// nonceKey identifies an account on a chain
+type nonceKey struct {
+	genesis types.Hash
+	account string
+}
+
+// accountNonce tracks the nonces of a single account
+type accountNonce struct {
+	m sync.Mutex
+	// next is the next nonce to hand out, zero means
+	// the nonce needs to be synced with the chain
+	next uint64
+}
+
+// nonceManager hands out sequential nonces per account, so the same identity
+// can have multiple extrinsics in flight at the same time. Each nonce is
+// reconciled with system_accountNextIndex, which also counts the account
+// transactions waiting in the pool.
+type nonceManager struct {
+	m        sync.Mutex
+	accounts map[nonceKey]*accountNonce
+}
+
+func newNonceManager() *nonceManager {
+	return &nonceManager{
+		accounts: make(map[nonceKey]*accountNonce),
+	}
+}
+
+func (n *nonceManager) account(genesis types.Hash, identity Identity) *accountNonce {
+	n.m.Lock()
+	defer n.m.Unlock()
+
+	key := nonceKey{genesis: genesis, account: identity.Address()}
+	account, ok := n.accounts[key]
+	if !ok {
+		account = &accountNonce{}
+		n.accounts[key] = account
+	}
+
+	return account
+}
+
+// next returns the nonce to use for the next extrinsic of identity
+func (n *nonceManager) next(cl Conn, genesis types.Hash, identity Identity) (uint64, error) {
+	account := n.account(genesis, identity)
+
+	account.m.Lock()
+	defer account.m.Unlock()
+
+	var chain uint64
+	if err := cl.Client.Call(&chain, "system_accountNextIndex", identity.Address()); err != nil {
+		return 0, errors.Wrap(err, "failed to get account next index")
+	}
+
+	nonce := account.next
+	if chain > nonce {
+		nonce = chain
+	}
+
+	account.next = nonce + 1
+	return nonce, nil
+}
+
+// reset forgets the local nonce of identity, the next nonce will be synced
+// with the chain. It must be called when an extrinsic is not going to be
+// included, so its nonce can be reused.
+func (n *nonceManager) reset(genesis types.Hash, identity Identity) {
+	account := n.account(genesis, identity)
+
+	account.m.Lock()
+	defer account.m.Unlock()
+
+	account.next = 0
+}
@@ -0,0 +1,106 @@
+package substrate
+
+import (
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNonceManagerConcurrent(t *testing.T) {
+	node := newFakeNode(t)
+	node.setNextIndex(3)
+
+	sub := testClient(t, node, DefaultManagerOptions())
+
+	cl, _, err := sub.GetClient()
+	require.NoError(t, err)
+
+	identity, err := NewIdentityFromSr25519Phrase("//Alice")
+	require.NoError(t, err)
+
+	var (
+		wg     sync.WaitGroup
+		m      sync.Mutex
+		nonces []int
+	)
+
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			nonce, err := sub.nonces.next(cl, sub.genesis, identity)
+			if !assertNoError(t, err) {
+				return
+			}
+			m.Lock()
+			nonces = append(nonces, int(nonce))
+			m.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	sort.Ints(nonces)
+	for i, nonce := range nonces {
+		require.Equal(t, 3+i, nonce)
+	}
+}
+
+func TestNonceManagerResync(t *testing.T) {
+	node := newFakeNode(t)
+	node.setNextIndex(3)
+
+	sub := testClient(t, node, DefaultManagerOptions())
+
+	cl, _, err := sub.GetClient()
+	require.NoError(t, err)
+
+	alice, err := NewIdentityFromSr25519Phrase("//Alice")
+	require.NoError(t, err)
+	bob, err := NewIdentityFromSr25519Phrase("//Bob")
+	require.NoError(t, err)
+
+	next := func(identity Identity) uint64 {
+		nonce, err := sub.nonces.next(cl, sub.genesis, identity)
+		require.NoError(t, err)
+		return nonce
+	}
+
+	require.EqualValues(t, 3, next(alice))
+	require.EqualValues(t, 4, next(alice))
+	// accounts are tracked separately
+	require.EqualValues(t, 3, next(bob))
+
+	// transactions were sent from somewhere else
+	node.setNextIndex(10)
+	require.EqualValues(t, 10, next(alice))
+
+	// a transaction was dropped, nonce is synced again from chain
+	node.setNextIndex(5)
+	require.EqualValues(t, 11, next(alice))
+	sub.nonces.reset(sub.genesis, alice)
+	require.EqualValues(t, 5, next(alice))
+}
+
+func TestCallOnceFailureResetsNonce(t *testing.T) {
+	node := newFakeNode(t)
+	node.setNextIndex(7)
+
+	sub := testClient(t, node, DefaultManagerOptions())
+
+	cl, meta, err := sub.GetClient()
+	require.NoError(t, err)
+
+	identity, err := NewIdentityFromSr25519Phrase("//Alice")
+	require.NoError(t, err)
+
+	// fake node doesn't accept extrinsics
+	_, err = sub.CallOnce(cl, meta, identity, types.Call{})
+	require.Error(t, err)
+
+	nonce, err := sub.nonces.next(cl, sub.genesis, identity)
+	require.NoError(t, err)
+	require.EqualValues(t, 7, nonce)
+}
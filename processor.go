@@ -0,0 +1,202 @@
+package substrate
+
+import (
+	"context"
+	"time"
+
+	"github.com/cenkalti/backoff"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// ProcessorOptions configures an event processor
+type ProcessorOptions struct {
+	// Start is the first block to process if no checkpoint was saved yet,
+	// 0 starts from the current head
+	Start uint32
+	// Finalized processes finalized blocks only
+	Finalized bool
+}
+
+// EventProcessor processes the events of every block in order and persists
+// the last processed block, so it can resume after a restart
+type EventProcessor struct {
+	sub   *Substrate
+	store CheckpointStore
+	opts  ProcessorOptions
+}
+
+// processError is returned when the handler or the checkpoint store fail,
+// unlike chain errors those are not retried
+type processError struct {
+	error
+}
+
+// NewEventProcessor creates an event processor over a substrate connection
+func NewEventProcessor(sub *Substrate, store CheckpointStore, opts ProcessorOptions) *EventProcessor {
+	return &EventProcessor{sub: sub, store: store, opts: opts}
+}
+
+// Run calls handler with the events of every block, starting after the saved
+// checkpoint. It catches up to the chain head in order, then follows new
+// blocks until ctx is canceled or handler fails. The checkpoint is saved after
+// handler returns, so a block can be handled again if the process stops
+// before that (at-least-once delivery). Blocks reverted by a chain
+// reorganization are passed to handler with Reverted set, and the checkpoint
+// moves back to their parent. Chain and connection errors are retried from
+// the last checkpoint.
+func (p *EventProcessor) Run(ctx context.Context, handler func(BlockEvents) error) error {
+	cp, ok, err := p.store.Load()
+	if err != nil {
+		return errors.Wrap(err, "failed to load checkpoint")
+	}
+
+	start := p.opts.Start
+	tracker := newChainTracker(reorgDepth)
+	if ok {
+		start = cp.Number + 1
+		// the checkpoint block is reverted if it is no longer canonical. The
+		// hash is unknown if the events of a reverted block were not available
+		if cp.Hash != (types.Hash{}) {
+			tracker.push(BlockEvents{Number: cp.Number, Hash: cp.Hash})
+		}
+	}
+
+	exp := backoff.NewExponentialBackOff()
+	exp.MaxElapsedTime = 0
+
+	process := func(block BlockEvents) error {
+		if err := handler(block); err != nil {
+			return processError{errors.Wrapf(err, "failed to process block %d", block.Number)}
+		}
+
+		cp := Checkpoint{Number: block.Number, Hash: block.Hash}
+		if block.Reverted {
+			cp = Checkpoint{Number: block.Number - 1, Hash: block.ParentHash}
+		}
+
+		if err := p.store.Save(cp); err != nil {
+			return processError{errors.Wrapf(err, "failed to save checkpoint of block %d", block.Number)}
+		}
+
+		exp.Reset()
+		return nil
+	}
+
+	for {
+		err := p.follow(ctx, start, tracker, process)
+		if perr, ok := err.(processError); ok {
+			return perr.error
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		select {
+		case <-p.sub.stop:
+			return errors.New("substrate connection closed")
+		default:
+		}
+
+		wait := exp.NextBackOff()
+		log.Warn().Err(err).Dur("retry", wait).Msg("event processor interrupted")
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// follow processes blocks after the last processed one (or from start) up to
+// the current head, then the heads received from a new subscription
+func (p *EventProcessor) follow(ctx context.Context, start uint32, tracker *chainTracker, process func(BlockEvents) error) error {
+	ctx, done := p.sub.track(ctx)
+	defer done()
+
+	cl, _, err := p.sub.getClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	// subscribe before getting the head, so no block is missed in between
+	sub, err := subscribeHeads(cl, p.opts.Finalized)
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+
+	head, err := p.head(cl)
+	if err != nil {
+		return err
+	}
+
+	next := start
+	if tip, ok := tracker.tip(); ok {
+		next = tip.Number + 1
+	} else if next == 0 {
+		next = head
+	}
+
+	for ; next <= head; next++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		header, err := headerAt(cl, next)
+		if err != nil {
+			return err
+		}
+
+		if err := p.sub.advance(cl, tracker, header, process); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-sub.Err():
+			if err == nil {
+				err = errors.New("subscription closed")
+			}
+			return errors.Wrap(err, "chain heads subscription failed")
+		case header, ok := <-sub.Chan():
+			if !ok {
+				return errors.New("chain heads subscription closed")
+			}
+
+			if err := p.sub.advance(cl, tracker, header, process); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// head gets the number of the current best or finalized head
+func (p *EventProcessor) head(cl Conn) (uint32, error) {
+	if !p.opts.Finalized {
+		header, err := cl.RPC.Chain.GetHeaderLatest()
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to get best head")
+		}
+
+		return uint32(header.Number), nil
+	}
+
+	hash, err := cl.RPC.Chain.GetFinalizedHead()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get finalized head")
+	}
+
+	header, err := cl.RPC.Chain.GetHeader(hash)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get finalized head")
+	}
+
+	return uint32(header.Number), nil
+}
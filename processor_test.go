@@ -0,0 +1,123 @@
+package substrate
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func receiveNumber(t *testing.T, ch <-chan uint32) uint32 {
+	select {
+	case n := <-ch:
+		return n
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for block")
+	}
+
+	return 0
+}
+
+func TestEventProcessorResume(t *testing.T) {
+	node := newFakeNode(t)
+	chain := newFakeChain(t, node)
+	for i := uint32(1); i <= 5; i++ {
+		chain.add(i, nil)
+	}
+
+	var store MemoryCheckpointStore
+	require.NoError(t, store.Save(Checkpoint{Number: 2}))
+
+	cl := testClient(t, node, DefaultManagerOptions())
+	processor := NewEventProcessor(cl, &store, ProcessorOptions{Start: 1})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	processed := make(chan uint32)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- processor.Run(ctx, func(block BlockEvents) error {
+			processed <- block.Number
+			return nil
+		})
+	}()
+
+	// catch up from the checkpoint to the head
+	for _, expected := range []uint32{3, 4, 5} {
+		require.Equal(t, expected, receiveNumber(t, processed))
+	}
+
+	// then follow new heads
+	require.Eventually(t, func() bool {
+		return node.subscribers("chain_subscribeNewHead") == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	chain.add(6, nil)
+	last := chain.add(7, nil)
+	chain.head(last, false)
+	for _, expected := range []uint32{6, 7} {
+		require.Equal(t, expected, receiveNumber(t, processed))
+	}
+
+	hash, err := headerHash(&last)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		cp, ok, err := store.Load()
+		return err == nil && ok && cp.Number == 7 && cp.Hash == hash
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	select {
+	case err := <-errCh:
+		require.ErrorIs(t, err, context.Canceled)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "processor did not stop")
+	}
+}
+
+func TestEventProcessorHandlerFailure(t *testing.T) {
+	node := newFakeNode(t)
+	chain := newFakeChain(t, node)
+	for i := uint32(1); i <= 5; i++ {
+		chain.add(i, nil)
+	}
+
+	var store MemoryCheckpointStore
+	cl := testClient(t, node, DefaultManagerOptions())
+	processor := NewEventProcessor(cl, &store, ProcessorOptions{Start: 1, Finalized: true})
+
+	var processed []uint32
+	err := processor.Run(context.Background(), func(block BlockEvents) error {
+		if block.Number == 4 {
+			return fmt.Errorf("handler failed")
+		}
+
+		processed = append(processed, block.Number)
+		return nil
+	})
+	require.EqualError(t, err, "failed to process block 4: handler failed")
+	require.Equal(t, []uint32{1, 2, 3}, processed)
+
+	cp, ok, err := store.Load()
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, uint32(3), cp.Number)
+
+	// resumes from the failed block
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	processed = nil
+	err = processor.Run(ctx, func(block BlockEvents) error {
+		processed = append(processed, block.Number)
+		if block.Number == 5 {
+			cancel()
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, []uint32{4, 5}, processed)
+}
@@ -0,0 +1,85 @@
+// Package prometheus implements substrate.Metrics with prometheus collectors
+package prometheus
+
+import (
+	"time"
+
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/threefoldtech/substrate-client"
+)
+
+const namespace = "substrate_client"
+
+var _ substrate.Metrics = (*Metrics)(nil)
+
+// Metrics reports the client activity to prometheus
+type Metrics struct {
+	connects     *prom.CounterVec
+	rpcs         *prom.HistogramVec
+	extrinsics   *prom.HistogramVec
+	moduleErrors *prom.CounterVec
+}
+
+// NewMetrics creates the client collectors and registers them with reg
+func NewMetrics(reg prom.Registerer) (*Metrics, error) {
+	m := &Metrics{
+		connects: prom.NewCounterVec(prom.CounterOpts{
+			Namespace: namespace,
+			Name:      "connection_attempts_total",
+			Help:      "Number of connection attempts per endpoint",
+		}, []string{"endpoint", "result"}),
+		rpcs: prom.NewHistogramVec(prom.HistogramOpts{
+			Namespace: namespace,
+			Name:      "rpc_duration_seconds",
+			Help:      "Duration of rpc calls per method",
+			Buckets:   prom.DefBuckets,
+		}, []string{"method", "result"}),
+		extrinsics: prom.NewHistogramVec(prom.HistogramOpts{
+			Namespace: namespace,
+			Name:      "extrinsic_duration_seconds",
+			Help:      "Time from extrinsic submission to its last status",
+			// blocks are produced every 6 seconds
+			Buckets: []float64{1, 3, 6, 9, 12, 18, 24, 30, 45, 60, 90, 120},
+		}, []string{"status"}),
+		moduleErrors: prom.NewCounterVec(prom.CounterOpts{
+			Namespace: namespace,
+			Name:      "module_errors_total",
+			Help:      "Number of module errors returned by calls",
+		}, []string{"pallet", "name"}),
+	}
+
+	for _, c := range []prom.Collector{m.connects, m.rpcs, m.extrinsics, m.moduleErrors} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// ObserveConnect implements substrate.Metrics
+func (m *Metrics) ObserveConnect(endpoint string, err error) {
+	m.connects.WithLabelValues(endpoint, result(err)).Inc()
+}
+
+// ObserveRPC implements substrate.Metrics
+func (m *Metrics) ObserveRPC(method string, duration time.Duration, err error) {
+	m.rpcs.WithLabelValues(method, result(err)).Observe(duration.Seconds())
+}
+
+// ObserveExtrinsic implements substrate.Metrics
+func (m *Metrics) ObserveExtrinsic(status string, duration time.Duration) {
+	m.extrinsics.WithLabelValues(status).Observe(duration.Seconds())
+}
+
+// ObserveModuleError implements substrate.Metrics
+func (m *Metrics) ObserveModuleError(pallet string, name string) {
+	m.moduleErrors.WithLabelValues(pallet, name).Inc()
+}
+
+func result(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "success"
+}
@@ -0,0 +1,42 @@
+package prometheus
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"github.com/threefoldtech/substrate-client"
+)
+
+func TestMetrics(t *testing.T) {
+	reg := prom.NewRegistry()
+	m, err := NewMetrics(reg)
+	require.NoError(t, err)
+
+	m.ObserveConnect("wss://tfchain.grid.tf", nil)
+	m.ObserveConnect("wss://tfchain.grid.tf", fmt.Errorf("refused"))
+	m.ObserveRPC("state_getStorage", 10*time.Millisecond, nil)
+	m.ObserveExtrinsic(substrate.ExtrinsicInBlock, 6*time.Second)
+	m.ObserveModuleError("TfgridModule", "TwinNotExists")
+
+	require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(`
+# HELP substrate_client_connection_attempts_total Number of connection attempts per endpoint
+# TYPE substrate_client_connection_attempts_total counter
+substrate_client_connection_attempts_total{endpoint="wss://tfchain.grid.tf",result="error"} 1
+substrate_client_connection_attempts_total{endpoint="wss://tfchain.grid.tf",result="success"} 1
+# HELP substrate_client_module_errors_total Number of module errors returned by calls
+# TYPE substrate_client_module_errors_total counter
+substrate_client_module_errors_total{name="TwinNotExists",pallet="TfgridModule"} 1
+`), "substrate_client_connection_attempts_total", "substrate_client_module_errors_total"))
+
+	require.Equal(t, 1, testutil.CollectAndCount(m.rpcs))
+	require.Equal(t, 1, testutil.CollectAndCount(m.extrinsics))
+
+	// collectors can't be registered twice
+	_, err = NewMetrics(reg)
+	require.Error(t, err)
+}
@@ -1,6 +1,7 @@
 package substrate
 
 import (
+	"context"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/pkg/errors"
 )
@@ -14,8 +15,13 @@ type RefundTransaction struct {
 	SequenceNumber types.U64
 }
 
-func (s *Substrate) CreateRefundTransactionOrAddSig(identity Identity, tx_hash string, target string, amount int64, signature string, stellarAddress string, sequence_number uint64) error {
-	cl, meta, err := s.GetClient()
+func (s *Substrate) CreateRefundTransactionOrAddSig(identity Identity, tx_hash string, target string, amount int64, signature string, stellarAddress string, sequence_number uint64, opts ...CallOption) error {
+	return s.CreateRefundTransactionOrAddSigCtx(context.Background(), identity, tx_hash, target, amount, signature, stellarAddress, sequence_number, opts...)
+}
+
+// CreateRefundTransactionOrAddSigCtx is like CreateRefundTransactionOrAddSig but takes a context
+func (s *Substrate) CreateRefundTransactionOrAddSigCtx(ctx context.Context, identity Identity, tx_hash string, target string, amount int64, signature string, stellarAddress string, sequence_number uint64, opts ...CallOption) error {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return err
 	}
@@ -28,7 +34,7 @@ func (s *Substrate) CreateRefundTransactionOrAddSig(identity Identity, tx_hash s
 		return errors.Wrap(err, "failed to create call")
 	}
 
-	_, err = s.Call(cl, meta, identity, c)
+	_, err = s.CallCtx(ctx, cl, meta, identity, c, opts...)
 	if err != nil {
 		return errors.Wrap(err, "failed to create refund transaction")
 	}
@@ -36,8 +42,13 @@ func (s *Substrate) CreateRefundTransactionOrAddSig(identity Identity, tx_hash s
 	return nil
 }
 
-func (s *Substrate) SetRefundTransactionExecuted(identity Identity, txHash string) error {
-	cl, meta, err := s.GetClient()
+func (s *Substrate) SetRefundTransactionExecuted(identity Identity, txHash string, opts ...CallOption) error {
+	return s.SetRefundTransactionExecutedCtx(context.Background(), identity, txHash, opts...)
+}
+
+// SetRefundTransactionExecutedCtx is like SetRefundTransactionExecuted but takes a context
+func (s *Substrate) SetRefundTransactionExecutedCtx(ctx context.Context, identity Identity, txHash string, opts ...CallOption) error {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return err
 	}
@@ -48,7 +59,7 @@ func (s *Substrate) SetRefundTransactionExecuted(identity Identity, txHash strin
 		return errors.Wrap(err, "failed to create call")
 	}
 
-	_, err = s.Call(cl, meta, identity, c)
+	_, err = s.CallCtx(ctx, cl, meta, identity, c, opts...)
 	if err != nil {
 		return errors.Wrap(err, "failed to create refund transaction")
 	}
@@ -57,7 +68,12 @@ func (s *Substrate) SetRefundTransactionExecuted(identity Identity, txHash strin
 }
 
 func (s *Substrate) IsRefundedAlready(txHash string) (exists bool, err error) {
-	cl, meta, err := s.GetClient()
+	return s.IsRefundedAlreadyCtx(context.Background(), txHash)
+}
+
+// IsRefundedAlreadyCtx is like IsRefundedAlready but takes a context
+func (s *Substrate) IsRefundedAlreadyCtx(ctx context.Context, txHash string) (exists bool, err error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return false, err
 	}
@@ -74,7 +90,7 @@ func (s *Substrate) IsRefundedAlready(txHash string) (exists bool, err error) {
 		return
 	}
 
-	ok, err := cl.RPC.State.GetStorageLatest(key, &refundTx)
+	ok, err := s.getStorage(cl, key, &refundTx)
 	if err != nil {
 		return false, err
 	}
@@ -87,7 +103,12 @@ func (s *Substrate) IsRefundedAlready(txHash string) (exists bool, err error) {
 }
 
 func (s *Substrate) GetRefundTransaction(txHash string) (*RefundTransaction, error) {
-	cl, meta, err := s.GetClient()
+	return s.GetRefundTransactionCtx(context.Background(), txHash)
+}
+
+// GetRefundTransactionCtx is like GetRefundTransaction but takes a context
+func (s *Substrate) GetRefundTransactionCtx(ctx context.Context, txHash string) (*RefundTransaction, error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -104,7 +125,7 @@ func (s *Substrate) GetRefundTransaction(txHash string) (*RefundTransaction, err
 		return nil, err
 	}
 
-	ok, err := cl.RPC.State.GetStorageLatest(key, &refundTx)
+	ok, err := s.getStorage(cl, key, &refundTx)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,184 @@
+package substrate
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// reorgDepth is the number of delivered blocks kept to detect chain reorganizations
+const reorgDepth = 256
+
+// chainTracker keeps the latest delivered blocks of the canonical chain, in order
+type chainTracker struct {
+	blocks []BlockEvents
+	depth  int
+}
+
+func newChainTracker(depth int) *chainTracker {
+	return &chainTracker{depth: depth}
+}
+
+// push adds the next delivered block
+func (t *chainTracker) push(block BlockEvents) {
+	t.blocks = append(t.blocks, block)
+	if len(t.blocks) > t.depth {
+		t.blocks = append(t.blocks[:0], t.blocks[len(t.blocks)-t.depth:]...)
+	}
+}
+
+// tip returns the last delivered block
+func (t *chainTracker) tip() (BlockEvents, bool) {
+	if len(t.blocks) == 0 {
+		return BlockEvents{}, false
+	}
+
+	return t.blocks[len(t.blocks)-1], true
+}
+
+// at returns the delivered block with the given number
+func (t *chainTracker) at(number uint32) (BlockEvents, bool) {
+	if len(t.blocks) == 0 {
+		return BlockEvents{}, false
+	}
+
+	first := t.blocks[0].Number
+	if number < first || number-first >= uint32(len(t.blocks)) {
+		return BlockEvents{}, false
+	}
+
+	return t.blocks[number-first], true
+}
+
+// revert removes all delivered blocks starting from number, and returns
+// them marked as reverted, latest first
+func (t *chainTracker) revert(number uint32) []BlockEvents {
+	var reverted []BlockEvents
+	for len(t.blocks) > 0 {
+		block := t.blocks[len(t.blocks)-1]
+		if block.Number < number {
+			break
+		}
+
+		t.blocks = t.blocks[:len(t.blocks)-1]
+		block.Reverted = true
+		reverted = append(reverted, block)
+	}
+
+	return reverted
+}
+
+// branch returns the headers from the last delivered ancestor of head up
+// to head, following parent hashes. It is empty if head was already delivered
+func (s *Substrate) branch(cl Conn, t *chainTracker, head types.Header) ([]types.Header, error) {
+	hash, err := headerHash(&head)
+	if err != nil {
+		return nil, err
+	}
+
+	if delivered, ok := t.at(uint32(head.Number)); ok && delivered.Hash == hash {
+		return nil, nil
+	}
+
+	if len(t.blocks) == 0 {
+		return []types.Header{head}, nil
+	}
+
+	first := t.blocks[0].Number
+	branch := []types.Header{head}
+	for {
+		current := branch[0]
+		number := uint32(current.Number)
+		if number == 0 || number-1 < first {
+			// no common ancestor in the delivered blocks
+			return branch, nil
+		}
+
+		if parent, ok := t.at(number - 1); ok && parent.Hash == current.ParentHash {
+			return branch, nil
+		}
+
+		parent, err := getHeader(cl, current.ParentHash)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get header of block %d", number-1)
+		}
+
+		branch = append([]types.Header{*parent}, branch...)
+	}
+}
+
+// advance delivers the blocks needed to move the delivered chain to head:
+// first the delivered blocks that are no longer canonical, marked as
+// reverted, then the new canonical blocks in order
+func (s *Substrate) advance(cl Conn, t *chainTracker, head types.Header, deliver func(BlockEvents) error) error {
+	branch, err := s.branch(cl, t, head)
+	if err != nil {
+		return err
+	}
+
+	if len(branch) == 0 {
+		return nil
+	}
+
+	for _, block := range t.revert(uint32(branch[0].Number)) {
+		if block.Events == nil {
+			// only the hash is known for the block, like a saved checkpoint
+			block = s.revertedEvents(cl, block)
+		}
+
+		log.Debug().Uint32("number", block.Number).Str("hash", block.Hash.Hex()).Msg("block reverted")
+		if err := deliver(block); err != nil {
+			return err
+		}
+	}
+
+	for _, header := range branch {
+		block := s.headerEvents(cl, header)
+		if block.Err != nil {
+			return block.Err
+		}
+
+		if err := deliver(block); err != nil {
+			return err
+		}
+		t.push(block)
+	}
+
+	return nil
+}
+
+// revertedEvents gets the events of a reverted block known only by hash.
+// Blocks off the canonical chain can be pruned by the node, in that case
+// the block is returned without events
+func (s *Substrate) revertedEvents(cl Conn, block BlockEvents) BlockEvents {
+	header, err := getHeader(cl, block.Hash)
+	if err == nil {
+		fetched := s.headerEvents(cl, *header)
+		if err = fetched.Err; err == nil {
+			fetched.Reverted = true
+			return fetched
+		}
+	}
+
+	log.Warn().Err(err).Uint32("number", block.Number).Msg("events of reverted block are not available")
+	return block
+}
+
+// getHeader gets the header of a block, failing if the node doesn't know the block
+func getHeader(cl Conn, hash types.Hash) (*types.Header, error) {
+	header, err := cl.RPC.Chain.GetHeader(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	got, err := headerHash(header)
+	if err != nil {
+		return nil, err
+	}
+
+	if got != hash {
+		return nil, errors.Errorf("block '%s' not found", hash.Hex())
+	}
+
+	return header, nil
+}
@@ -0,0 +1,204 @@
+package substrate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/require"
+)
+
+func requireBlock(t *testing.T, block BlockEvents, header types.Header, reverted bool) {
+	require.NoError(t, block.Err)
+	require.Equal(t, uint32(header.Number), block.Number)
+	require.Equal(t, reverted, block.Reverted)
+
+	hash, err := headerHash(&header)
+	require.NoError(t, err)
+	require.Equal(t, hash, block.Hash)
+	require.Equal(t, header.ParentHash, block.ParentHash)
+}
+
+func TestChainTracker(t *testing.T) {
+	tracker := newChainTracker(3)
+	for i := uint32(1); i <= 5; i++ {
+		tracker.push(BlockEvents{Number: i})
+	}
+
+	_, ok := tracker.at(2)
+	require.False(t, ok)
+	block, ok := tracker.at(4)
+	require.True(t, ok)
+	require.Equal(t, uint32(4), block.Number)
+
+	reverted := tracker.revert(4)
+	require.Len(t, reverted, 2)
+	require.Equal(t, uint32(5), reverted[0].Number)
+	require.Equal(t, uint32(4), reverted[1].Number)
+	require.True(t, reverted[0].Reverted)
+
+	tip, ok := tracker.tip()
+	require.True(t, ok)
+	require.Equal(t, uint32(3), tip.Number)
+}
+
+func TestSubscribeEventsReorg(t *testing.T) {
+	node := newFakeNode(t)
+	chain := newFakeChain(t, node)
+
+	// Utility.ItemCompleted
+	itemCompleted := []byte{1 << 2, 0, 0, 0, 0, 0, 1, 2, 0}
+
+	first := chain.add(1, nil)
+	a2 := chain.add(2, itemCompleted)
+	a3 := chain.add(3, nil)
+
+	cl := testClient(t, node, DefaultManagerOptions())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch, err := cl.SubscribeEvents(ctx, SubscribeOptions{})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return node.subscribers("chain_subscribeNewHead") == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	chain.head(first, false)
+	requireBlock(t, receiveBlock(t, ch), first, false)
+	chain.head(a3, false)
+	requireBlock(t, receiveBlock(t, ch), a2, false)
+	requireBlock(t, receiveBlock(t, ch), a3, false)
+
+	// same head again is not delivered twice
+	chain.head(a3, false)
+
+	// a longer fork from block 1 replaces blocks 2 and 3
+	b2 := chain.fork(first, 1, nil)
+	b3 := chain.fork(b2, 1, nil)
+	b4 := chain.fork(b3, 1, nil)
+	chain.head(b4, false)
+
+	reverted := receiveBlock(t, ch)
+	requireBlock(t, reverted, a3, true)
+	reverted = receiveBlock(t, ch)
+	requireBlock(t, reverted, a2, true)
+	require.Len(t, reverted.Events.Utility_ItemCompleted, 1)
+
+	for _, header := range []types.Header{b2, b3, b4} {
+		requireBlock(t, receiveBlock(t, ch), header, false)
+	}
+
+	var handlers EventHandlers
+	var handled, undone []uint32
+	handlers.OnBlock(func(block BlockEvents) {
+		handled = append(handled, block.Number)
+	})
+	handlers.OnRevert(func(block BlockEvents) {
+		undone = append(undone, block.Number)
+	})
+	handlers.Handle(reverted)
+	require.Empty(t, handled)
+	require.Equal(t, []uint32{2}, undone)
+}
+
+func TestEventProcessorReorg(t *testing.T) {
+	node := newFakeNode(t)
+	chain := newFakeChain(t, node)
+
+	chain.add(1, nil)
+	a2 := chain.add(2, nil)
+	a3 := chain.add(3, nil)
+
+	var store MemoryCheckpointStore
+	cl := testClient(t, node, DefaultManagerOptions())
+	processor := NewEventProcessor(cl, &store, ProcessorOptions{Start: 1})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	processed := make(chan BlockEvents)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- processor.Run(ctx, func(block BlockEvents) error {
+			processed <- block
+			return nil
+		})
+	}()
+
+	for i := 0; i < 3; i++ {
+		receiveBlock(t, processed)
+	}
+	require.Eventually(t, func() bool {
+		return node.subscribers("chain_subscribeNewHead") == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	b3 := chain.fork(a2, 1, nil)
+	b4 := chain.fork(b3, 1, nil)
+	chain.head(b4, false)
+
+	requireBlock(t, receiveBlock(t, processed), a3, true)
+	cp, ok, err := store.Load()
+	require.NoError(t, err)
+	require.True(t, ok)
+	a2Hash, err := headerHash(&a2)
+	require.NoError(t, err)
+	require.Equal(t, Checkpoint{Number: 2, Hash: a2Hash}, cp)
+
+	requireBlock(t, receiveBlock(t, processed), b3, false)
+	requireBlock(t, receiveBlock(t, processed), b4, false)
+
+	b4Hash, err := headerHash(&b4)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		cp, ok, err := store.Load()
+		return err == nil && ok && cp == Checkpoint{Number: 4, Hash: b4Hash}
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.ErrorIs(t, <-errCh, context.Canceled)
+}
+
+func TestEventProcessorCheckpointReverted(t *testing.T) {
+	node := newFakeNode(t)
+	chain := newFakeChain(t, node)
+
+	// Utility.ItemCompleted
+	itemCompleted := []byte{1 << 2, 0, 0, 0, 0, 0, 1, 2, 0}
+
+	chain.add(1, nil)
+	a2 := chain.add(2, nil)
+	a3 := chain.add(3, itemCompleted)
+
+	// the processor stopped at block 3, which was then replaced by a fork
+	a3Hash, err := headerHash(&a3)
+	require.NoError(t, err)
+	var store MemoryCheckpointStore
+	require.NoError(t, store.Save(Checkpoint{Number: 3, Hash: a3Hash}))
+
+	b3 := chain.fork(a2, 1, nil)
+	b4 := chain.fork(b3, 1, nil)
+
+	cl := testClient(t, node, DefaultManagerOptions())
+	processor := NewEventProcessor(cl, &store, ProcessorOptions{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var processed []BlockEvents
+	err = processor.Run(ctx, func(block BlockEvents) error {
+		processed = append(processed, block)
+		if block.Number == 4 {
+			cancel()
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, context.Canceled)
+
+	require.Len(t, processed, 3)
+	requireBlock(t, processed[0], a3, true)
+	require.Len(t, processed[0].Events.Utility_ItemCompleted, 1)
+	requireBlock(t, processed[1], b3, false)
+	requireBlock(t, processed[2], b4, false)
+}
@@ -1,24 +1,36 @@
 package substrate
 
 import (
+	"context"
 	"net"
+	"time"
 
 	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/author"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/beefy"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/mmr"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/offchain"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/system"
 	"github.com/pkg/errors"
 )
 
 type retryingClient struct {
 	client.Client
+	metrics Metrics
 }
 
-func newRetryingClient(cl client.Client) retryingClient {
-	return retryingClient{cl}
+func newRetryingClient(cl client.Client, metrics Metrics) retryingClient {
+	return retryingClient{Client: cl, metrics: metrics}
 }
 
-func (c *retryingClient) Call(result interface{}, method string, args ...interface{}) error {
-	err := c.Client.Call(result, method, args...)
+func (c *retryingClient) Call(result interface{}, method string, args ...interface{}) (err error) {
+	defer c.observe(method, time.Now(), &err)
+
+	err = c.Client.Call(result, method, args...)
 	// if connection is closed, retrying should reconnect
 	if errors.Is(err, net.ErrClosed) {
 		err = c.Client.Call(result, method, args...)
@@ -26,19 +38,50 @@ func (c *retryingClient) Call(result interface{}, method string, args ...interfa
 	return err
 }
 
-func newSubstrateAPI(url string) (*gsrpc.SubstrateAPI, error) {
-	cl, err := client.Connect(url)
-	if err != nil {
-		return nil, err
+func (c *retryingClient) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) (err error) {
+	cl, ok := c.Client.(contextCaller)
+	if !ok {
+		return c.Call(result, method, args...)
+	}
+
+	defer c.observe(method, time.Now(), &err)
+
+	err = cl.CallContext(ctx, result, method, args...)
+	// if connection is closed, retrying should reconnect
+	if errors.Is(err, net.ErrClosed) {
+		err = cl.CallContext(ctx, result, method, args...)
 	}
-	rcl := newRetryingClient(cl)
-	newRPC, err := rpc.NewRPC(&rcl)
+	return err
+}
+
+// observe reports a call that started at start and returned err
+func (c *retryingClient) observe(method string, start time.Time, err *error) {
+	c.metrics.ObserveRPC(method, time.Since(start), *err)
+}
+
+func newSubstrateAPI(url string, metrics Metrics) (*gsrpc.SubstrateAPI, error) {
+	cl, err := client.Connect(url)
 	if err != nil {
 		return nil, err
 	}
+	rcl := newRetryingClient(cl, metrics)
 
 	return &gsrpc.SubstrateAPI{
-		RPC:    newRPC,
+		RPC:    newRPC(&rcl),
 		Client: &rcl,
 	}, nil
 }
+
+// newRPC creates the rpc api over the client. Unlike rpc.NewRPC it doesn't
+// download the chain metadata, since metadata is cached by the manager
+func newRPC(cl client.Client) *rpc.RPC {
+	return &rpc.RPC{
+		Author:   author.NewAuthor(cl),
+		Beefy:    beefy.NewBeefy(cl),
+		Chain:    chain.NewChain(cl),
+		MMR:      mmr.NewMMR(cl),
+		Offchain: offchain.NewOffchain(cl),
+		State:    state.NewState(cl),
+		System:   system.NewSystem(cl),
+	}
+}
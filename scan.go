@@ -0,0 +1,141 @@
+package substrate
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/pkg/errors"
+)
+
+// scannedEntry is the raw value of a storage map entry
+type scannedEntry struct {
+	id    uint64
+	value types.StorageDataRaw
+	err   error
+}
+
+// scanWindow is the outcome of the query of a window of ids
+type scanWindow struct {
+	from, to uint64
+	values   map[string]types.StorageDataRaw
+	keys     []types.StorageKey
+	err      error
+}
+
+// scan gets the values of the entries from-to of a storage map keyed by id.
+// The keys of ChunkSize ids are queried with a single state_queryStorageAt
+// call, and up to Concurrency windows are queried in parallel. Entries are
+// sent in order of their ids, missing entries have an ErrNotFound error.
+// All values are read at the same block.
+func (s *Substrate) scan(ctx context.Context, pallet, storage string, from, to uint64, encode func(id uint64) ([]byte, error), opts []RangeOption) (<-chan scannedEntry, error) {
+	if to < from {
+		return nil, errors.Errorf("invalid range %d-%d", from, to)
+	}
+
+	options := newRangeOptions(opts)
+
+	cl, meta, err := s.getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := s.iterBlock(cl)
+	if err != nil {
+		return nil, err
+	}
+
+	query := func(from, to uint64) scanWindow {
+		window := scanWindow{from: from, to: to}
+		for id := from; id <= to; id++ {
+			arg, err := encode(id)
+			if err != nil {
+				window.err = errors.Wrap(err, "substrate: encoding error building query arguments")
+				return window
+			}
+
+			key, err := types.CreateStorageKey(meta, pallet, storage, arg)
+			if err != nil {
+				window.err = errors.Wrap(err, "failed to create substrate query key")
+				return window
+			}
+			window.keys = append(window.keys, key)
+		}
+
+		window.values, window.err = queryStorageAt(cl, window.keys, block)
+		if window.err != nil {
+			window.err = errors.Wrap(window.err, "failed to lookup entities")
+		}
+		return window
+	}
+
+	// windows are queried in parallel but delivered in order, the queue
+	// holds the pending windows in order
+	queue := make(chan chan scanWindow, options.Concurrency-1)
+	go func() {
+		defer close(queue)
+
+		for start := from; start <= to; start += uint64(options.ChunkSize) {
+			end := start + uint64(options.ChunkSize) - 1
+			if end > to || end < start {
+				end = to
+			}
+
+			pending := make(chan scanWindow, 1)
+			select {
+			case <-ctx.Done():
+				return
+			case queue <- pending:
+			}
+
+			go func(from, to uint64) {
+				pending <- query(from, to)
+			}(start, end)
+
+			if end == to {
+				return
+			}
+		}
+	}()
+
+	ch := make(chan scannedEntry)
+	go func() {
+		defer close(ch)
+
+		for pending := range queue {
+			var window scanWindow
+			select {
+			case <-ctx.Done():
+				return
+			case window = <-pending:
+			}
+
+			for id := window.from; id <= window.to; id++ {
+				entry := scannedEntry{id: id, err: window.err}
+				if entry.err == nil {
+					value, ok := window.values[window.keys[id-window.from].Hex()]
+					if ok && len(value) != 0 {
+						entry.value = value
+					} else {
+						entry.err = ErrNotFound
+					}
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case ch <- entry:
+				}
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+func encodeU32(id uint64) ([]byte, error) {
+	return types.Encode(uint32(id))
+}
+
+func encodeU64(id uint64) ([]byte, error) {
+	return types.Encode(id)
+}
@@ -0,0 +1,63 @@
+package substrate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScan(t *testing.T) {
+	node := newFakeNode(t)
+	entries := map[uint32][]byte{}
+	for id := uint32(1); id <= 10; id++ {
+		if id == 4 || id == 7 {
+			continue
+		}
+		entries[id] = []byte{byte(id)}
+	}
+	withStorageMap(t, node, "Indices", "Accounts", entries)
+
+	sub, _ := callTestClient(t, node)
+
+	ch, err := sub.scan(context.Background(), "Indices", "Accounts", 1, 12, encodeU32, []RangeOption{WithChunkSize(3), WithConcurrency(3)})
+	require.NoError(t, err)
+
+	var ids []uint64
+	for entry := range ch {
+		ids = append(ids, entry.id)
+		if value, ok := entries[uint32(entry.id)]; ok {
+			require.NoError(t, entry.err)
+			require.Equal(t, value, []byte(entry.value))
+		} else {
+			require.True(t, errors.Is(entry.err, ErrNotFound), "entry %d", entry.id)
+		}
+	}
+
+	require.Equal(t, []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, ids)
+	require.Equal(t, 4, node.count("state_queryStorageAt"))
+
+	_, err = sub.scan(context.Background(), "Indices", "Accounts", 5, 1, encodeU32, nil)
+	require.Error(t, err)
+}
+
+func TestScanCanceled(t *testing.T) {
+	node := newFakeNode(t)
+	withStorageMap(t, node, "Indices", "Accounts", map[uint32][]byte{1: {1}})
+
+	sub, _ := callTestClient(t, node)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err := sub.scan(ctx, "Indices", "Accounts", 1, 1000, encodeU32, []RangeOption{WithChunkSize(10)})
+	require.NoError(t, err)
+
+	entry := <-ch
+	require.NoError(t, entry.err)
+	cancel()
+
+	// the channel is closed once the scan stops
+	for range ch {
+	}
+	require.Less(t, node.count("state_queryStorageAt"), 100)
+}
@@ -1,6 +1,7 @@
 package substrate
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
@@ -72,7 +73,12 @@ func (r ServiceContractState) Encode(encoder scale.Encoder) (err error) {
 
 // ServiceContractCreate creates a service contract
 func (s *Substrate) ServiceContractCreate(identity Identity, service AccountID, consumer AccountID) (uint64, error) {
-	cl, meta, err := s.GetClient()
+	return s.ServiceContractCreateCtx(context.Background(), identity, service, consumer)
+}
+
+// ServiceContractCreateCtx is like ServiceContractCreate but takes a context
+func (s *Substrate) ServiceContractCreateCtx(ctx context.Context, identity Identity, service AccountID, consumer AccountID) (uint64, error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -85,7 +91,7 @@ func (s *Substrate) ServiceContractCreate(identity Identity, service AccountID,
 		return 0, errors.Wrap(err, "failed to create call")
 	}
 
-	callResponse, err := s.Call(cl, meta, identity, c)
+	callResponse, err := s.CallCtx(ctx, cl, meta, identity, c)
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to create service contract")
 	}
@@ -100,7 +106,12 @@ func (s *Substrate) ServiceContractCreate(identity Identity, service AccountID,
 
 // ServiceContractSetMetadata sets metadata for a service contract
 func (s *Substrate) ServiceContractSetMetadata(identity Identity, contract uint64, metadata string) error {
-	cl, meta, err := s.GetClient()
+	return s.ServiceContractSetMetadataCtx(context.Background(), identity, contract, metadata)
+}
+
+// ServiceContractSetMetadataCtx is like ServiceContractSetMetadata but takes a context
+func (s *Substrate) ServiceContractSetMetadataCtx(ctx context.Context, identity Identity, contract uint64, metadata string) error {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return err
 	}
@@ -113,7 +124,7 @@ func (s *Substrate) ServiceContractSetMetadata(identity Identity, contract uint6
 		return errors.Wrap(err, "failed to create call")
 	}
 
-	_, err = s.Call(cl, meta, identity, c)
+	_, err = s.CallCtx(ctx, cl, meta, identity, c)
 	if err != nil {
 		return errors.Wrap(err, "failed to set metadata for service contract")
 	}
@@ -123,7 +134,12 @@ func (s *Substrate) ServiceContractSetMetadata(identity Identity, contract uint6
 
 // ServiceContractSetFees sets fees for a service contract
 func (s *Substrate) ServiceContractSetFees(identity Identity, contract uint64, base_fee uint64, variable_fee uint64) error {
-	cl, meta, err := s.GetClient()
+	return s.ServiceContractSetFeesCtx(context.Background(), identity, contract, base_fee, variable_fee)
+}
+
+// ServiceContractSetFeesCtx is like ServiceContractSetFees but takes a context
+func (s *Substrate) ServiceContractSetFeesCtx(ctx context.Context, identity Identity, contract uint64, base_fee uint64, variable_fee uint64) error {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return err
 	}
@@ -136,7 +152,7 @@ func (s *Substrate) ServiceContractSetFees(identity Identity, contract uint64, b
 		return errors.Wrap(err, "failed to create call")
 	}
 
-	_, err = s.Call(cl, meta, identity, c)
+	_, err = s.CallCtx(ctx, cl, meta, identity, c)
 	if err != nil {
 		return errors.Wrap(err, "failed to set fees for service contract")
 	}
@@ -146,7 +162,12 @@ func (s *Substrate) ServiceContractSetFees(identity Identity, contract uint64, b
 
 // ServiceContractApprove approves a service contract
 func (s *Substrate) ServiceContractApprove(identity Identity, contract uint64) error {
-	cl, meta, err := s.GetClient()
+	return s.ServiceContractApproveCtx(context.Background(), identity, contract)
+}
+
+// ServiceContractApproveCtx is like ServiceContractApprove but takes a context
+func (s *Substrate) ServiceContractApproveCtx(ctx context.Context, identity Identity, contract uint64) error {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return err
 	}
@@ -159,7 +180,7 @@ func (s *Substrate) ServiceContractApprove(identity Identity, contract uint64) e
 		return errors.Wrap(err, "failed to create call")
 	}
 
-	_, err = s.Call(cl, meta, identity, c)
+	_, err = s.CallCtx(ctx, cl, meta, identity, c)
 	if err != nil {
 		return errors.Wrap(err, "failed to approve service contract")
 	}
@@ -169,7 +190,12 @@ func (s *Substrate) ServiceContractApprove(identity Identity, contract uint64) e
 
 // ServiceContractReject rejects a service contract
 func (s *Substrate) ServiceContractReject(identity Identity, contract uint64) error {
-	cl, meta, err := s.GetClient()
+	return s.ServiceContractRejectCtx(context.Background(), identity, contract)
+}
+
+// ServiceContractRejectCtx is like ServiceContractReject but takes a context
+func (s *Substrate) ServiceContractRejectCtx(ctx context.Context, identity Identity, contract uint64) error {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return err
 	}
@@ -182,7 +208,7 @@ func (s *Substrate) ServiceContractReject(identity Identity, contract uint64) er
 		return errors.Wrap(err, "failed to create call")
 	}
 
-	_, err = s.Call(cl, meta, identity, c)
+	_, err = s.CallCtx(ctx, cl, meta, identity, c)
 	if err != nil {
 		return errors.Wrap(err, "failed to reject service contract")
 	}
@@ -192,7 +218,12 @@ func (s *Substrate) ServiceContractReject(identity Identity, contract uint64) er
 
 // ServiceContractCancel cancels a service contract
 func (s *Substrate) ServiceContractCancel(identity Identity, contract uint64) error {
-	cl, meta, err := s.GetClient()
+	return s.ServiceContractCancelCtx(context.Background(), identity, contract)
+}
+
+// ServiceContractCancelCtx is like ServiceContractCancel but takes a context
+func (s *Substrate) ServiceContractCancelCtx(ctx context.Context, identity Identity, contract uint64) error {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return err
 	}
@@ -205,7 +236,7 @@ func (s *Substrate) ServiceContractCancel(identity Identity, contract uint64) er
 		return errors.Wrap(err, "failed to create call")
 	}
 
-	_, err = s.Call(cl, meta, identity, c)
+	_, err = s.CallCtx(ctx, cl, meta, identity, c)
 	if err != nil {
 		return errors.Wrap(err, "failed to cancel service contract")
 	}
@@ -215,7 +246,12 @@ func (s *Substrate) ServiceContractCancel(identity Identity, contract uint64) er
 
 // ServiceContractBill bills a service contract
 func (s *Substrate) ServiceContractBill(identity Identity, contract uint64, variable_amount uint64, metadata string) error {
-	cl, meta, err := s.GetClient()
+	return s.ServiceContractBillCtx(context.Background(), identity, contract, variable_amount, metadata)
+}
+
+// ServiceContractBillCtx is like ServiceContractBill but takes a context
+func (s *Substrate) ServiceContractBillCtx(ctx context.Context, identity Identity, contract uint64, variable_amount uint64, metadata string) error {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return err
 	}
@@ -228,7 +264,7 @@ func (s *Substrate) ServiceContractBill(identity Identity, contract uint64, vari
 		return errors.Wrap(err, "failed to create call")
 	}
 
-	_, err = s.Call(cl, meta, identity, c)
+	_, err = s.CallCtx(ctx, cl, meta, identity, c)
 	if err != nil {
 		return errors.Wrap(err, "failed to bill service contract")
 	}
@@ -238,7 +274,12 @@ func (s *Substrate) ServiceContractBill(identity Identity, contract uint64, vari
 
 // GetServiceContract gets a service contract given the service contract id
 func (s *Substrate) GetServiceContract(id uint64) (*ServiceContract, error) {
-	cl, meta, err := s.GetClient()
+	return s.GetServiceContractCtx(context.Background(), id)
+}
+
+// GetServiceContractCtx is like GetServiceContract but takes a context
+func (s *Substrate) GetServiceContractCtx(ctx context.Context, id uint64) (*ServiceContract, error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -253,7 +294,7 @@ func (s *Substrate) GetServiceContract(id uint64) (*ServiceContract, error) {
 		return nil, errors.Wrap(err, "failed to create substrate query key")
 	}
 
-	raw, err := cl.RPC.State.GetStorageRawLatest(key)
+	raw, err := s.getStorageRaw(cl, key)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to lookup contract")
 	}
@@ -272,7 +313,12 @@ func (s *Substrate) GetServiceContract(id uint64) (*ServiceContract, error) {
 
 // GetServiceContractID gets the current value of storage ServiceContractID
 func (s *Substrate) GetServiceContractID() (uint64, error) {
-	cl, meta, err := s.GetClient()
+	return s.GetServiceContractIDCtx(context.Background())
+}
+
+// GetServiceContractIDCtx is like GetServiceContractID but takes a context
+func (s *Substrate) GetServiceContractIDCtx(ctx context.Context) (uint64, error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -282,7 +328,7 @@ func (s *Substrate) GetServiceContractID() (uint64, error) {
 		return 0, errors.Wrap(err, "failed to create substrate query key")
 	}
 	var id types.U64
-	ok, err := cl.RPC.State.GetStorageLatest(key, &id)
+	ok, err := s.getStorage(cl, key, &id)
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to lookup entity")
 	}
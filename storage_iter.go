@@ -0,0 +1,296 @@
+package substrate
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/xxhash"
+	"github.com/pkg/errors"
+)
+
+const defaultIterPageSize = 100
+
+// IterOptions configures storage map iterations
+type IterOptions struct {
+	// PageSize is the number of entries fetched per rpc call
+	PageSize uint32
+	// StartKey is the storage key the iteration starts after, it's
+	// the key of the last entry of an interrupted iteration to resume it
+	StartKey types.StorageKey
+}
+
+// IterOption sets an option of a storage map iteration
+type IterOption func(*IterOptions)
+
+// WithPageSize sets the number of entries fetched per rpc call
+func WithPageSize(size uint32) IterOption {
+	return func(o *IterOptions) {
+		o.PageSize = size
+	}
+}
+
+// WithStartKey starts the iteration after the given storage key
+func WithStartKey(key types.StorageKey) IterOption {
+	return func(o *IterOptions) {
+		o.StartKey = key
+	}
+}
+
+func newIterOptions(opts []IterOption) IterOptions {
+	options := IterOptions{PageSize: defaultIterPageSize}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if options.PageSize == 0 {
+		options.PageSize = defaultIterPageSize
+	}
+
+	return options
+}
+
+// StorageEntry is an entry of a storage map
+type StorageEntry struct {
+	// Key is the full storage key of the entry
+	Key types.StorageKey
+	// MapKey is the SCALE encoded key of the entry in the map. It's only
+	// set for maps with a single key hashed with a concat or identity hasher
+	MapKey []byte
+	// Value is the SCALE encoded value
+	Value types.StorageDataRaw
+}
+
+// IterStorage calls fn for every entry of a storage map, in the order of
+// their storage keys (not the order of the map keys). All pages are read at
+// the block the iteration starts at, or at the pinned block. An error returned
+// by fn stops the iteration and is returned.
+func (s *Substrate) IterStorage(ctx context.Context, pallet, storage string, fn func(entry StorageEntry) error, opts ...IterOption) error {
+	options := newIterOptions(opts)
+
+	cl, meta, err := s.getClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	hasher, err := mapHasher(meta, pallet, storage)
+	if err != nil {
+		return err
+	}
+
+	block, err := s.iterBlock(cl)
+	if err != nil {
+		return err
+	}
+
+	prefix := storagePrefix(pallet, storage)
+	start := options.StartKey
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		keys, err := getKeysPaged(cl, prefix, options.PageSize, start, block)
+		if err != nil {
+			return errors.Wrapf(err, "failed to list keys of %s.%s", pallet, storage)
+		}
+
+		if len(keys) == 0 {
+			return nil
+		}
+
+		values, err := queryStorageAt(cl, keys, block)
+		if err != nil {
+			return errors.Wrapf(err, "failed to query values of %s.%s", pallet, storage)
+		}
+
+		for _, key := range keys {
+			value, ok := values[key.Hex()]
+			if !ok {
+				// keys and values are both read at block, so a listed key
+				// always has a value unless the node answered inconsistently
+				return errors.Errorf("missing value of %s.%s key %s", pallet, storage, key.Hex())
+			}
+
+			entry := StorageEntry{Key: key, Value: value}
+			if hasher >= 0 && len(key) >= len(prefix)+hasher {
+				entry.MapKey = key[len(prefix)+hasher:]
+			}
+
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			if err := fn(entry); err != nil {
+				return err
+			}
+		}
+
+		if uint32(len(keys)) < options.PageSize {
+			return nil
+		}
+
+		start = keys[len(keys)-1]
+	}
+}
+
+// IterFarms calls fn for every farm, see IterStorage
+func (s *Substrate) IterFarms(ctx context.Context, fn func(id uint32, farm *Farm) error, opts ...IterOption) error {
+	return s.IterStorage(ctx, "TfgridModule", "Farms", func(entry StorageEntry) error {
+		var id uint32
+		if err := types.Decode(entry.MapKey, &id); err != nil {
+			return errors.Wrap(err, "failed to decode farm id")
+		}
+
+		farm, err := s.decodeFarm(entry.Value)
+		if err != nil {
+			return errors.Wrapf(err, "failed to decode farm %d", id)
+		}
+
+		return fn(id, farm)
+	}, opts...)
+}
+
+// IterTwins calls fn for every twin, see IterStorage
+func (s *Substrate) IterTwins(ctx context.Context, fn func(id uint32, twin *Twin) error, opts ...IterOption) error {
+	return s.IterStorage(ctx, "TfgridModule", "Twins", func(entry StorageEntry) error {
+		var id uint32
+		if err := types.Decode(entry.MapKey, &id); err != nil {
+			return errors.Wrap(err, "failed to decode twin id")
+		}
+
+		var twin Twin
+		if err := types.Decode(entry.Value, &twin); err != nil {
+			return errors.Wrapf(err, "failed to decode twin %d", id)
+		}
+
+		return fn(id, &twin)
+	}, opts...)
+}
+
+// IterNodes calls fn for every node, see IterStorage
+func (s *Substrate) IterNodes(ctx context.Context, fn func(id uint32, node *Node) error, opts ...IterOption) error {
+	return s.IterStorage(ctx, "TfgridModule", "Nodes", func(entry StorageEntry) error {
+		var id uint32
+		if err := types.Decode(entry.MapKey, &id); err != nil {
+			return errors.Wrap(err, "failed to decode node id")
+		}
+
+		var node Node
+		if err := types.Decode(entry.Value, &node); err != nil {
+			return errors.Wrapf(err, "failed to decode node %d", id)
+		}
+
+		return fn(id, &node)
+	}, opts...)
+}
+
+// IterContracts calls fn for every contract, see IterStorage
+func (s *Substrate) IterContracts(ctx context.Context, fn func(id uint64, contract *Contract) error, opts ...IterOption) error {
+	return s.IterStorage(ctx, "SmartContractModule", "Contracts", func(entry StorageEntry) error {
+		var id uint64
+		if err := types.Decode(entry.MapKey, &id); err != nil {
+			return errors.Wrap(err, "failed to decode contract id")
+		}
+
+		var contract Contract
+		if err := types.Decode(entry.Value, &contract); err != nil {
+			return errors.Wrapf(err, "failed to decode contract %d", id)
+		}
+
+		return fn(id, &contract)
+	}, opts...)
+}
+
+// iterBlock is the block an iteration reads from, the pinned block
+// or the latest block
+func (s *Substrate) iterBlock(cl Conn) (types.Hash, error) {
+	if s.at != nil {
+		return *s.at, nil
+	}
+
+	block, err := cl.RPC.Chain.GetBlockHashLatest()
+	return block, errors.Wrap(err, "failed to get latest block hash")
+}
+
+// getKeysPaged lists up to count keys with prefix after start at block
+func getKeysPaged(cl Conn, prefix types.StorageKey, count uint32, start types.StorageKey, block types.Hash) ([]types.StorageKey, error) {
+	var after interface{}
+	if len(start) != 0 {
+		after = start.Hex()
+	}
+
+	var result []string
+	if err := cl.Client.Call(&result, "state_getKeysPaged", prefix.Hex(), count, after, block.Hex()); err != nil {
+		return nil, err
+	}
+
+	keys := make([]types.StorageKey, 0, len(result))
+	for _, key := range result {
+		data, err := types.HexDecodeString(key)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid storage key '%s'", key)
+		}
+		keys = append(keys, data)
+	}
+
+	return keys, nil
+}
+
+// queryStorageAt gets the values of keys at block, keyed by the hex of the key.
+// Keys without a value are not included
+func queryStorageAt(cl Conn, keys []types.StorageKey, block types.Hash) (map[string]types.StorageDataRaw, error) {
+	sets, err := cl.RPC.State.QueryStorageAt(keys, block)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]types.StorageDataRaw, len(keys))
+	for _, set := range sets {
+		for _, change := range set.Changes {
+			if !change.HasStorageData {
+				continue
+			}
+			values[change.StorageKey.Hex()] = change.StorageData
+		}
+	}
+
+	return values, nil
+}
+
+// storagePrefix is the prefix of all keys of a storage item
+func storagePrefix(pallet, storage string) types.StorageKey {
+	prefix := xxhash.New128([]byte(pallet)).Sum(nil)
+	return append(prefix, xxhash.New128([]byte(storage)).Sum(nil)...)
+}
+
+// mapHasher checks that the storage is a map, and returns the length of the
+// hash that precedes the encoded key of its entries, or -1 if the key can't be
+// decoded from the storage key
+func mapHasher(meta Meta, pallet, storage string) (int, error) {
+	entry, err := meta.FindStorageEntryMetadata(pallet, storage)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to find %s.%s", pallet, storage)
+	}
+
+	if !entry.IsMap() {
+		return 0, errors.Errorf("%s.%s is not a map", pallet, storage)
+	}
+
+	v14, ok := entry.(types.StorageEntryMetadataV14)
+	if !ok || len(v14.Type.AsMap.Hashers) != 1 {
+		return -1, nil
+	}
+
+	switch hasher := v14.Type.AsMap.Hashers[0]; {
+	case hasher.IsBlake2_128Concat:
+		return 16, nil
+	case hasher.IsTwox64Concat:
+		return 8, nil
+	case hasher.IsIdentity:
+		return 0, nil
+	default:
+		return -1, nil
+	}
+}
@@ -0,0 +1,162 @@
+package substrate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/require"
+)
+
+// withStorageMap serves a storage map from the fake node with
+// state_getKeysPaged and state_queryStorageAt
+func withStorageMap(t *testing.T, node *fakeNode, pallet, storage string, entries map[uint32][]byte) {
+	values := make(map[string]string)
+	var keys []string
+	for id, value := range entries {
+		arg, err := types.Encode(id)
+		require.NoError(t, err)
+		key, err := types.CreateStorageKey(node.meta, pallet, storage, arg)
+		require.NoError(t, err)
+
+		keys = append(keys, key.Hex())
+		values[key.Hex()] = types.HexEncodeToString(value)
+	}
+	sort.Strings(keys)
+
+	node.handle("state_getKeysPaged", func(params []json.RawMessage) (interface{}, error) {
+		var (
+			prefix, start string
+			count         int
+		)
+		if err := json.Unmarshal(params[0], &prefix); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(params[1], &count); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(params[2], &start); err != nil {
+			return nil, err
+		}
+
+		page := []string{}
+		for _, key := range keys {
+			if len(page) == count {
+				break
+			}
+			if strings.HasPrefix(key, prefix) && key > start {
+				page = append(page, key)
+			}
+		}
+		return page, nil
+	})
+
+	node.handle("state_queryStorageAt", func(params []json.RawMessage) (interface{}, error) {
+		var keys []string
+		if err := json.Unmarshal(params[0], &keys); err != nil {
+			return nil, err
+		}
+
+		var changes [][]interface{}
+		for _, key := range keys {
+			if value, ok := values[key]; ok {
+				changes = append(changes, []interface{}{key, value})
+			} else {
+				changes = append(changes, []interface{}{key, nil})
+			}
+		}
+		return []interface{}{map[string]interface{}{"block": types.Hash{}.Hex(), "changes": changes}}, nil
+	})
+}
+
+func TestIterStorage(t *testing.T) {
+	node := newFakeNode(t)
+	entries := map[uint32][]byte{}
+	for id := uint32(1); id <= 5; id++ {
+		entries[id] = []byte{byte(id), 0xff}
+	}
+	withStorageMap(t, node, "Indices", "Accounts", entries)
+
+	sub, _ := callTestClient(t, node)
+	ctx := context.Background()
+
+	var seen []StorageEntry
+	err := sub.IterStorage(ctx, "Indices", "Accounts", func(entry StorageEntry) error {
+		seen = append(seen, entry)
+		return nil
+	}, WithPageSize(2))
+	require.NoError(t, err)
+	require.Len(t, seen, 5)
+	require.Equal(t, 3, node.count("state_getKeysPaged"))
+
+	got := map[uint32][]byte{}
+	for _, entry := range seen {
+		var id uint32
+		require.NoError(t, types.Decode(entry.MapKey, &id))
+		got[id] = entry.Value
+	}
+	require.Equal(t, entries, got)
+
+	// all pages are read at the same block
+	params := node.lastParams("state_getKeysPaged")
+	require.Equal(t, `"`+types.Hash{}.Hex()+`"`, string(params[3]))
+
+	// resume after the second entry
+	var resumed int
+	err = sub.IterStorage(ctx, "Indices", "Accounts", func(entry StorageEntry) error {
+		resumed++
+		return nil
+	}, WithStartKey(seen[1].Key))
+	require.NoError(t, err)
+	require.Equal(t, 3, resumed)
+
+	// errors of fn stop the iteration
+	stop := fmt.Errorf("stop")
+	var calls int
+	err = sub.IterStorage(ctx, "Indices", "Accounts", func(entry StorageEntry) error {
+		calls++
+		return stop
+	})
+	require.Equal(t, stop, err)
+	require.Equal(t, 1, calls)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	err = sub.IterStorage(canceled, "Indices", "Accounts", func(entry StorageEntry) error {
+		return nil
+	})
+	require.ErrorIs(t, err, context.Canceled)
+
+	err = sub.IterStorage(ctx, "System", "Number", func(entry StorageEntry) error {
+		return nil
+	})
+	require.EqualError(t, err, "System.Number is not a map")
+}
+
+func TestStorageMapHasher(t *testing.T) {
+	var meta types.Metadata
+	require.NoError(t, types.DecodeFromHex(types.MetadataV14Data, &meta))
+
+	for _, tc := range []struct {
+		pallet, storage string
+		hasher          int
+	}{
+		{"Indices", "Accounts", 16},
+		{"Staking", "ErasStartSessionIndex", 8},
+		{"Democracy", "Preimages", 0},
+		// double map
+		{"Staking", "ErasStakers", -1},
+	} {
+		hasher, err := mapHasher(&meta, tc.pallet, tc.storage)
+		require.NoError(t, err)
+		require.Equal(t, tc.hasher, hasher, "%s.%s", tc.pallet, tc.storage)
+	}
+
+	key, err := types.CreateStorageKey(&meta, "System", "Number", nil)
+	require.NoError(t, err)
+	require.Equal(t, key, storagePrefix("System", "Number"))
+}
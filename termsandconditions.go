@@ -1,6 +1,7 @@
 package substrate
 
 import (
+	"context"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/pkg/errors"
 )
@@ -14,7 +15,12 @@ type TermsAndConditions struct {
 
 // AcceptTermsAndConditions accepts terms and conditions
 func (s *Substrate) AcceptTermsAndConditions(identity Identity, documentLink string, documentHash string) error {
-	cl, meta, err := s.GetClient()
+	return s.AcceptTermsAndConditionsCtx(context.Background(), identity, documentLink, documentHash)
+}
+
+// AcceptTermsAndConditionsCtx is like AcceptTermsAndConditions but takes a context
+func (s *Substrate) AcceptTermsAndConditionsCtx(ctx context.Context, identity Identity, documentLink string, documentHash string) error {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return err
 	}
@@ -27,7 +33,7 @@ func (s *Substrate) AcceptTermsAndConditions(identity Identity, documentLink str
 		return errors.Wrap(err, "failed to create call")
 	}
 
-	_, err = s.Call(cl, meta, identity, c)
+	_, err = s.CallCtx(ctx, cl, meta, identity, c)
 	if err != nil {
 		return errors.Wrap(err, "failed to accept terms and conditions")
 	}
@@ -37,7 +43,12 @@ func (s *Substrate) AcceptTermsAndConditions(identity Identity, documentLink str
 
 // SignedTermsAndConditions return list of signed terms and conditions for this account
 func (s *Substrate) SignedTermsAndConditions(account AccountID) ([]TermsAndConditions, error) {
-	cl, meta, err := s.GetClient()
+	return s.SignedTermsAndConditionsCtx(context.Background(), account)
+}
+
+// SignedTermsAndConditionsCtx is like SignedTermsAndConditions but takes a context
+func (s *Substrate) SignedTermsAndConditionsCtx(ctx context.Context, account AccountID) ([]TermsAndConditions, error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -51,7 +62,7 @@ func (s *Substrate) SignedTermsAndConditions(account AccountID) ([]TermsAndCondi
 		return nil, errors.Wrap(err, "failed to create substrate query key")
 	}
 
-	raw, err := cl.RPC.State.GetStorageRawLatest(key)
+	raw, err := s.getStorageRaw(cl, key)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to lookup terms and conditions")
 	}
@@ -1,6 +1,7 @@
 package substrate
 
 import (
+	"context"
 	"math/big"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
@@ -8,7 +9,12 @@ import (
 )
 
 func (s *Substrate) Transfer(identity Identity, amount uint64, destination AccountID) error {
-	cl, meta, err := s.GetClient()
+	return s.TransferCtx(context.Background(), identity, amount, destination)
+}
+
+// TransferCtx is like Transfer but takes a context
+func (s *Substrate) TransferCtx(ctx context.Context, identity Identity, amount uint64, destination AccountID) error {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return err
 	}
@@ -25,7 +31,7 @@ func (s *Substrate) Transfer(identity Identity, amount uint64, destination Accou
 		return errors.Wrap(err, "failed to create call")
 	}
 
-	_, err = s.Call(cl, meta, identity, c)
+	_, err = s.CallCtx(ctx, cl, meta, identity, c)
 	if err != nil {
 		return errors.Wrap(err, "failed to transfer")
 	}
@@ -1,6 +1,7 @@
 package substrate
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
@@ -70,7 +71,12 @@ func (r *OptionRelay) Decode(decoder scale.Decoder) error {
 
 // GetTwinByPubKey gets a twin with public key
 func (s *Substrate) GetTwinByPubKey(pk []byte) (uint32, error) {
-	cl, meta, err := s.GetClient()
+	return s.GetTwinByPubKeyCtx(context.Background(), pk)
+}
+
+// GetTwinByPubKeyCtx is like GetTwinByPubKey but takes a context
+func (s *Substrate) GetTwinByPubKeyCtx(ctx context.Context, pk []byte) (uint32, error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -80,7 +86,7 @@ func (s *Substrate) GetTwinByPubKey(pk []byte) (uint32, error) {
 		return 0, errors.Wrap(err, "failed to create substrate query key")
 	}
 	var id types.U32
-	ok, err := cl.RPC.State.GetStorageLatest(key, &id)
+	ok, err := s.getStorage(cl, key, &id)
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to lookup entity")
 	}
@@ -94,7 +100,12 @@ func (s *Substrate) GetTwinByPubKey(pk []byte) (uint32, error) {
 
 // GetTwin gets a twin
 func (s *Substrate) GetTwin(id uint32) (*Twin, error) {
-	cl, meta, err := s.GetClient()
+	return s.GetTwinCtx(context.Background(), id)
+}
+
+// GetTwinCtx is like GetTwin but takes a context
+func (s *Substrate) GetTwinCtx(ctx context.Context, id uint32) (*Twin, error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -108,7 +119,7 @@ func (s *Substrate) GetTwin(id uint32) (*Twin, error) {
 		return nil, errors.Wrap(err, "failed to create substrate query key")
 	}
 
-	raw, err := cl.RPC.State.GetStorageRawLatest(key)
+	raw, err := s.getStorageRaw(cl, key)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to lookup entity")
 	}
@@ -127,7 +138,12 @@ func (s *Substrate) GetTwin(id uint32) (*Twin, error) {
 
 // CreateTwin creates a twin
 func (s *Substrate) CreateTwin(identity Identity, relay string, pk []byte) (uint32, error) {
-	cl, meta, err := s.GetClient()
+	return s.CreateTwinCtx(context.Background(), identity, relay, pk)
+}
+
+// CreateTwinCtx is like CreateTwin but takes a context
+func (s *Substrate) CreateTwinCtx(ctx context.Context, identity Identity, relay string, pk []byte) (uint32, error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -147,16 +163,21 @@ func (s *Substrate) CreateTwin(identity Identity, relay string, pk []byte) (uint
 		return 0, errors.Wrap(err, "failed to create call")
 	}
 
-	if _, err := s.Call(cl, meta, identity, c); err != nil {
+	if _, err := s.CallCtx(ctx, cl, meta, identity, c); err != nil {
 		return 0, errors.Wrap(err, "failed to create twin")
 	}
 
-	return s.GetTwinByPubKey(identity.PublicKey())
+	return s.GetTwinByPubKeyCtx(ctx, identity.PublicKey())
 }
 
 // UpdateTwin updates a twin
 func (s *Substrate) UpdateTwin(identity Identity, relay string, pk []byte) (uint32, error) {
-	cl, meta, err := s.GetClient()
+	return s.UpdateTwinCtx(context.Background(), identity, relay, pk)
+}
+
+// UpdateTwinCtx is like UpdateTwin but takes a context
+func (s *Substrate) UpdateTwinCtx(ctx context.Context, identity Identity, relay string, pk []byte) (uint32, error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -176,9 +197,48 @@ func (s *Substrate) UpdateTwin(identity Identity, relay string, pk []byte) (uint
 		return 0, errors.Wrap(err, "failed to create call")
 	}
 
-	if _, err := s.Call(cl, meta, identity, c); err != nil {
+	if _, err := s.CallCtx(ctx, cl, meta, identity, c); err != nil {
 		return 0, errors.Wrap(err, "failed to update twin")
 	}
 
-	return s.GetTwinByPubKey(identity.PublicKey())
+	return s.GetTwinByPubKeyCtx(ctx, identity.PublicKey())
+}
+
+// ScannedTwin is a twin found by ScanTwins
+type ScannedTwin struct {
+	ID   uint32
+	Twin Twin
+	Err  error
+}
+
+// ScanTwins is like ScanNodes but gets twins
+func (s *Substrate) ScanTwins(ctx context.Context, from, to uint32, opts ...RangeOption) (<-chan ScannedTwin, error) {
+	entries, err := s.scan(ctx, "TfgridModule", "Twins", uint64(from), uint64(to), encodeU32, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan ScannedTwin)
+	go func() {
+		defer close(ch)
+
+		for entry := range entries {
+			scanned := ScannedTwin{ID: uint32(entry.id), Err: entry.err}
+			if errors.Is(scanned.Err, ErrNotFound) {
+				scanned.Err = errors.Wrap(ErrNotFound, "twin not found")
+			} else if scanned.Err == nil {
+				if err := types.Decode(entry.value, &scanned.Twin); err != nil {
+					scanned.Err = errors.Wrap(err, "failed to load object")
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case ch <- scanned:
+			}
+		}
+	}()
+
+	return ch, nil
 }
@@ -1,6 +1,7 @@
 package substrate
 
 import (
+	"context"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/pkg/errors"
 )
@@ -17,7 +18,12 @@ type User struct {
 
 // GetUser with id
 func (s *Substrate) GetUser(id uint32) (*User, error) {
-	cl, meta, err := s.GetClient()
+	return s.GetUserCtx(context.Background(), id)
+}
+
+// GetUserCtx is like GetUser but takes a context
+func (s *Substrate) GetUserCtx(ctx context.Context, id uint32) (*User, error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -31,7 +37,7 @@ func (s *Substrate) GetUser(id uint32) (*User, error) {
 		return nil, errors.Wrap(err, "failed to create substrate query key")
 	}
 
-	raw, err := cl.RPC.State.GetStorageRawLatest(key)
+	raw, err := s.getStorageRaw(cl, key)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to lookup entity")
 	}
@@ -1,6 +1,7 @@
 package substrate
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -16,7 +17,17 @@ var (
 	Gigabyte     = 1024 * 1024 * 1024
 )
 
-// map from module index to error list
+const (
+	// callTimeout is how long a call waits to be included
+	// in a block if the context has no deadline
+	callTimeout = 30 * time.Second
+	// finalizedTimeout is how long a call waits for its block
+	// to be finalized if the context has no deadline
+	finalizedTimeout = 2 * time.Minute
+)
+
+// map from module index to error list, only used as a fallback
+// if the error is not found in the runtime metadata
 // https://github.com/threefoldtech/tfchain/blob/development/substrate-node/runtime/src/lib.rs#L701
 var moduleErrors = [][]string{
 	nil,                       // System
@@ -239,10 +250,20 @@ var tftBridgeModuleErrors = []string{
 }
 
 type CallResponse struct {
+	// Hash of the block that includes the extrinsic
 	Hash     types.Hash
 	Events   *EventRecords
 	Block    *types.SignedBlock
 	Identity Identity
+	// Finality is the inclusion level reached by the extrinsic
+	Finality Finality
+	// FinalizedHash is the hash of the finalized block that includes the
+	// extrinsic. It's only set if the WaitFinalized level was reached
+	FinalizedHash types.Hash
+	// ExtrinsicHash is the hash of the submitted extrinsic
+	ExtrinsicHash types.Hash
+	// BatchResults holds the result of each call of a batch
+	BatchResults []BatchResult
 }
 
 // Sign signs data with the private key under the given derivation path, returning the signature. Requires the subkey
@@ -326,9 +347,33 @@ func (s *Substrate) sign(e *types.Extrinsic, signer Identity, o types.SignatureO
 }
 
 // Call call this extrinsic and retry if Usurped
-func (s *Substrate) Call(cl Conn, meta Meta, identity Identity, call types.Call) (response *CallResponse, err error) {
+func (s *Substrate) Call(cl Conn, meta Meta, identity Identity, call types.Call, opts ...CallOption) (response *CallResponse, err error) {
+	return s.CallCtx(context.Background(), cl, meta, identity, call, opts...)
+}
+
+// CallCtx is like Call but takes a context. Each attempt is bound
+// by the context deadline (see CallOnceCtx)
+func (s *Substrate) CallCtx(ctx context.Context, cl Conn, meta Meta, identity Identity, call types.Call, opts ...CallOption) (response *CallResponse, err error) {
+	if s.at != nil {
+		return nil, ErrPinned
+	}
+
+	options := newCallOptions(opts)
+
+	cl = withContext(ctx, cl)
+	if options.DryRunFirst {
+		ext, err := s.dryRunExtrinsic(cl, meta, identity, call, options)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := s.dryRun(cl, meta, ext); err != nil {
+			return nil, errors.Wrap(err, "dry run failed")
+		}
+	}
+
 	for {
-		hash, err := s.CallOnce(cl, meta, identity, call)
+		submitted, err := s.submit(ctx, cl, identity, call, options)
 
 		if errors.Is(err, ErrIsUsurped) {
 			continue
@@ -338,63 +383,124 @@ func (s *Substrate) Call(cl Conn, meta Meta, identity Identity, call types.Call)
 			return nil, err
 		}
 
-		events, block, err := s.getEventRecords(cl, meta, hash)
-		if err != nil {
-			return nil, errors.Wrapf(err, "error extracting events from block(%s)", hash.Hex())
-		}
 		callResponse := CallResponse{
-			Hash:     hash,
-			Block:    block,
-			Events:   events,
-			Identity: identity,
+			Hash:          submitted.block,
+			Identity:      identity,
+			Finality:      submitted.finality,
+			FinalizedHash: submitted.finalized,
+			ExtrinsicHash: submitted.extrinsic,
 		}
-		err = s.checkForError(&callResponse)
+
+		if submitted.finality == FireAndForget {
+			// nothing to check, the extrinsic is not in a block yet
+			return &callResponse, nil
+		}
+
+		events, block, err := s.getEventRecords(cl, meta, submitted.block)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error extracting events from block(%s)", submitted.block.Hex())
+		}
+
+		callResponse.Block = block
+		callResponse.Events = events
+		err = s.checkForError(meta, &callResponse)
 		if err != nil {
+			var moduleErr *ModuleError
+			if errors.As(err, &moduleErr) {
+				s.metrics.ObserveModuleError(moduleErr.Pallet, moduleErr.Name)
+			}
 			return nil, err
 		}
 		return &callResponse, err
 	}
 }
 
-func (s *Substrate) CallOnce(cl Conn, meta Meta, identity Identity, call types.Call) (hash types.Hash, err error) {
-	// Create the extrinsic
-	ext := types.NewExtrinsic(call)
+// CallOnce submits the extrinsic and waits for it to be included in a block, or
+// finalized if WaitFinalized is set. The returned hash is the hash of that block
+func (s *Substrate) CallOnce(cl Conn, meta Meta, identity Identity, call types.Call, opts ...CallOption) (hash types.Hash, err error) {
+	return s.CallOnceCtx(context.Background(), cl, meta, identity, call, opts...)
+}
 
-	genesisHash, err := cl.RPC.Chain.GetBlockHash(0)
-	if err != nil {
-		return hash, errors.Wrap(err, "failed to get genesisHash")
+// CallOnceCtx is like CallOnce but takes a context. If ctx has no deadline
+// the call times out after callTimeout waiting for the block
+func (s *Substrate) CallOnceCtx(ctx context.Context, cl Conn, meta Meta, identity Identity, call types.Call, opts ...CallOption) (hash types.Hash, err error) {
+	if s.at != nil {
+		return hash, ErrPinned
 	}
 
-	rv, err := cl.RPC.State.GetRuntimeVersionLatest()
+	submitted, err := s.submit(ctx, withContext(ctx, cl), identity, call, newCallOptions(opts))
 	if err != nil {
 		return hash, err
 	}
 
-	//node.Address =identity.PublicKey
-	account, err := s.getAccount(cl, meta, identity)
+	return submitted.block, nil
+}
+
+// submission is the outcome of a submitted extrinsic
+type submission struct {
+	extrinsic types.Hash
+	block     types.Hash
+	finalized types.Hash
+	finality  Finality
+}
+
+func (s *Substrate) submit(ctx context.Context, cl Conn, identity Identity, call types.Call, options CallOptions) (submitted submission, err error) {
+	if _, ok := ctx.Deadline(); !ok {
+		timeout := callTimeout
+		if options.Finality == WaitFinalized {
+			timeout = finalizedTimeout
+		}
+
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	cl = withContext(ctx, cl)
+
+	nonce, err := s.nonces.next(cl, s.genesis, identity)
 	if err != nil {
-		return hash, errors.Wrap(err, "failed to get account")
+		return submitted, errors.Wrap(err, "failed to get account nonce")
 	}
 
-	o := types.SignatureOptions{
-		BlockHash:          genesisHash,
-		Era:                types.ExtrinsicEra{IsMortalEra: false},
-		GenesisHash:        genesisHash,
-		Nonce:              types.NewUCompactFromUInt(uint64(account.Nonce)),
-		SpecVersion:        rv.SpecVersion,
-		Tip:                types.NewUCompactFromUInt(0),
-		TransactionVersion: rv.TransactionVersion,
+	defer func() {
+		// the extrinsic didn't make it, so the nonce must
+		// be synced again with the chain on next call
+		if err != nil {
+			s.nonces.reset(s.genesis, identity)
+		}
+	}()
+
+	ext, err := s.extrinsic(cl, identity, call, nonce, options)
+	if err != nil {
+		return submitted, err
 	}
 
-	err = s.sign(&ext, identity, o)
+	if options.Finality == FireAndForget {
+		submitted.finality = FireAndForget
+		submitted.extrinsic, err = cl.RPC.Author.SubmitExtrinsic(ext)
+		if err != nil {
+			return submitted, errors.Wrap(err, "failed to submit extrinsic")
+		}
+
+		return submitted, nil
+	}
+
+	encoded, err := types.Encode(ext)
 	if err != nil {
-		return hash, errors.Wrap(err, "failed to sign")
+		return submitted, errors.Wrap(err, "failed to encode extrinsic")
 	}
+	submitted.extrinsic = blake2b.Sum256(encoded)
+
+	status, start := ExtrinsicError, time.Now()
+	defer func() {
+		s.metrics.ObserveExtrinsic(status, time.Since(start))
+	}()
 
 	// Send the extrinsic
 	sub, err := cl.RPC.Author.SubmitAndWatchExtrinsic(ext)
 	if err != nil {
-		return hash, errors.Wrap(err, "failed to submit extrinsic")
+		return submitted, errors.Wrap(err, "failed to submit extrinsic")
 	}
 
 	defer sub.Unsubscribe()
@@ -406,33 +512,104 @@ loop:
 	for {
 		select {
 		case err := <-ech:
-			return hash, errors.Wrap(err, "error failed on extrinsic status")
-		case <-time.After(30 * time.Second):
-			return hash, fmt.Errorf("extrinsic timeout waiting for block")
+			return submitted, errors.Wrap(err, "error failed on extrinsic status")
+		case <-ctx.Done():
+			status = ExtrinsicTimeout
+			return submitted, errors.Wrap(ctx.Err(), "extrinsic timeout waiting for block")
 		case event := <-ch:
-			if event.IsReady || event.IsBroadcast {
+			if event.IsReady || event.IsBroadcast || event.IsFuture {
 				continue
 			} else if event.IsInBlock {
-				hash = event.AsInBlock
-				break loop
+				submitted.block = event.AsInBlock
+				submitted.finality = WaitInBlock
+				if options.Finality == WaitInBlock {
+					status = ExtrinsicInBlock
+					break loop
+				}
+			} else if event.IsRetracted {
+				// block was reverted, the extrinsic is back in
+				// the pool. wait for it to be included again
+				log.Debug().Str("block", event.AsRetracted.Hex()).Msg("extrinsic block retracted")
 			} else if event.IsFinalized {
-				// we shouldn't hit this case
+				// with WaitInBlock we shouldn't hit this case
 				// any more since InBlock will always
 				// happen first we leave it only
 				// as a safety net
-				hash = event.AsFinalized
+				submitted.block = event.AsFinalized
+				submitted.finalized = event.AsFinalized
+				submitted.finality = WaitFinalized
+				status = ExtrinsicFinalized
 				break loop
+			} else if event.IsFinalityTimeout {
+				status = ExtrinsicFinalityTimeout
+				return submitted, fmt.Errorf("extrinsic finality timeout in block(%s)", event.AsFinalityTimeout.Hex())
 			} else if event.IsDropped || event.IsInvalid {
-				return hash, fmt.Errorf("failed to make call")
+				status = ExtrinsicDropped
+				if event.IsInvalid {
+					status = ExtrinsicInvalid
+				}
+				return submitted, fmt.Errorf("failed to make call")
 			} else if event.IsUsurped {
-				return hash, ErrIsUsurped
+				status = ExtrinsicUsurped
+				return submitted, ErrIsUsurped
 			} else {
 				log.Error().Err(err).Msgf("extrinsic block in an unhandled state: %+v", event)
 			}
 		}
 	}
 
-	return hash, nil
+	return submitted, nil
+}
+
+// extrinsic creates and signs an extrinsic of call with the given nonce
+func (s *Substrate) extrinsic(cl Conn, identity Identity, call types.Call, nonce uint64, options CallOptions) (ext types.Extrinsic, err error) {
+	// Create the extrinsic
+	ext = types.NewExtrinsic(call)
+
+	genesisHash, err := cl.RPC.Chain.GetBlockHash(0)
+	if err != nil {
+		return ext, errors.Wrap(err, "failed to get genesisHash")
+	}
+
+	rv, err := cl.RPC.State.GetRuntimeVersionLatest()
+	if err != nil {
+		return ext, err
+	}
+
+	// immortal extrinsics are checked against the genesis block, mortal
+	// ones against the block their era starts at
+	blockHash := genesisHash
+	era := types.ExtrinsicEra{IsMortalEra: false}
+	if options.MortalPeriod > 0 {
+		blockHash, err = cl.RPC.Chain.GetBlockHashLatest()
+		if err != nil {
+			return ext, errors.Wrap(err, "failed to get best block hash")
+		}
+
+		header, err := cl.RPC.Chain.GetHeader(blockHash)
+		if err != nil {
+			return ext, errors.Wrap(err, "failed to get best block header")
+		}
+
+		era = newMortalEra(uint64(header.Number), options.MortalPeriod)
+	}
+
+	o := types.SignatureOptions{
+		BlockHash:          blockHash,
+		Era:                era,
+		GenesisHash:        genesisHash,
+		Nonce:              types.NewUCompactFromUInt(nonce),
+		SpecVersion:        rv.SpecVersion,
+		Tip:                types.NewUCompactFromUInt(options.Tip),
+		TransactionVersion: rv.TransactionVersion,
+	}
+
+	err = s.sign(&ext, identity, o)
+	if err != nil {
+		return ext, errors.Wrap(err, "failed to sign")
+	}
+
+	return ext, nil
 }
 
 func (s *Substrate) getEventRecords(cl Conn, meta Meta, blockHash types.Hash) (*EventRecords, *types.SignedBlock, error) {
@@ -452,7 +629,7 @@ func (s *Substrate) getEventRecords(cl Conn, meta Meta, blockHash types.Hash) (*
 	}
 
 	events := EventRecords{}
-	err = types.EventRecordsRaw(*raw).DecodeEventRecords(meta, &events)
+	err = decodeEventRecords(meta, 0, types.EventRecordsRaw(*raw), &events)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "failed to decode event")
 	}
@@ -466,31 +643,23 @@ func (s *Substrate) getServiceContractIdsFromEvents(callResponse *CallResponse)
 	if err != nil {
 		return serviceContractIDs, err
 	}
-	if len(callResponse.Events.SmartContractModule_ServiceContractCreated) > 0 {
-		for _, e := range callResponse.Events.SmartContractModule_ServiceContractCreated {
-			if e.ServiceContract.ServiceTwinID == types.U32(twinID) ||
-				e.ServiceContract.ConsumerTwinID == types.U32(twinID) {
-				serviceContractIDs = append(serviceContractIDs, uint64(e.ServiceContract.ServiceContractID))
-			}
+
+	filter := EventFilter{Twins: []uint32{twinID}}
+	for _, e := range filter.Events(callResponse.Events) {
+		if created, ok := e.Event.(ServiceContractCreated); ok && e.Name == "SmartContractModule_ServiceContractCreated" {
+			serviceContractIDs = append(serviceContractIDs, uint64(created.ServiceContract.ServiceContractID))
 		}
 	}
 
 	return serviceContractIDs, nil
 }
 
-func (s *Substrate) checkForError(callResponse *CallResponse) error {
+func (s *Substrate) checkForError(meta Meta, callResponse *CallResponse) error {
 	if len(callResponse.Events.System_ExtrinsicFailed) > 0 {
 		for _, e := range callResponse.Events.System_ExtrinsicFailed {
 			who := callResponse.Block.Block.Extrinsics[e.Phase.AsApplyExtrinsic].Signature.Signer.AsID
 			if types.NewAccountID(callResponse.Identity.PublicKey()) == who {
-				if int(e.DispatchError.ModuleError.Index) < len(moduleErrors) {
-					if int(e.DispatchError.ModuleError.Error) >= len(moduleErrors[e.DispatchError.ModuleError.Index]) || moduleErrors[e.DispatchError.ModuleError.Index] == nil {
-						return fmt.Errorf("module error (%d) with unknown code %d occured, please update the module error list", e.DispatchError.ModuleError.Index, e.DispatchError.ModuleError.Error)
-					}
-					return fmt.Errorf(moduleErrors[e.DispatchError.ModuleError.Index][e.DispatchError.ModuleError.Error])
-				} else {
-					return fmt.Errorf("unknown module error (%d) with code %d occured, please create the module error list", e.DispatchError.ModuleError.Index, e.DispatchError.ModuleError.Error)
-				}
+				return dispatchError(meta, e.DispatchError)
 			}
 		}
 	}
@@ -0,0 +1,258 @@
+package substrate
+
+import (
+	"encoding/base32"
+	"net"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// limits enforced by the tfgrid pallet, inputs are validated locally
+// before submitting extrinsics so mistakes don't cost fees
+const (
+	minFarmNameLength = 3
+	maxFarmNameLength = 40
+
+	minIP4Length = 9
+	maxIP4Length = 18
+	minGW4Length = 7
+	maxGW4Length = 15
+	minIP6Length = 2
+	maxIP6Length = 43
+	minGW6Length = 2
+	maxGW6Length = 39
+
+	minDomainLength = 3
+	maxDomainLength = 128
+
+	stellarAddressLength = 56
+	// stellarAccountVersion is the version byte of stellar account ids (G...)
+	stellarAccountVersion = 6 << 3
+)
+
+// validateFarmName checks the farm name is 3 to 40 characters
+// of letters, digits, '-' and '_'
+func validateFarmName(name string) error {
+	if len(name) < minFarmNameLength {
+		return errors.Wrapf(ErrFarmNameTooShort, "farm name '%s' is shorter than %d characters", name, minFarmNameLength)
+	}
+
+	if len(name) > maxFarmNameLength {
+		return errors.Wrapf(ErrFarmNameTooLong, "farm name '%s' is longer than %d characters", name, maxFarmNameLength)
+	}
+
+	for _, c := range name {
+		if !isAlphanumeric(c) && c != '-' && c != '_' {
+			return errors.Wrapf(ErrInvalidFarmName, "farm name '%s' can only have letters, digits, '-' and '_'", name)
+		}
+	}
+
+	return nil
+}
+
+// ipErrors are the errors of an ip in CIDR notation and its gateway
+type ipErrors struct {
+	ipTooShort, ipTooLong, invalidIP error
+	gwTooShort, gwTooLong, invalidGW error
+}
+
+var (
+	// publicIPErrors are the errors of the public ips of farms
+	publicIPErrors = ipErrors{
+		ipTooShort: ErrPublicIPTooShort,
+		ipTooLong:  ErrPublicIPTooLong,
+		invalidIP:  ErrInvalidPublicIP,
+		gwTooShort: ErrGatewayIPTooShort,
+		gwTooLong:  ErrGatewayIPTooLong,
+		invalidGW:  ErrInvalidPublicIP,
+	}
+
+	// ip4Errors are the errors of the IPv4 of node public configs
+	ip4Errors = ipErrors{
+		ipTooShort: ErrIP4TooShort,
+		ipTooLong:  ErrIP4TooLong,
+		invalidIP:  ErrInvalidIP4,
+		gwTooShort: ErrGW4TooShort,
+		gwTooLong:  ErrGW4TooLong,
+		invalidGW:  ErrInvalidGW4,
+	}
+
+	// ip6Errors are the errors of the IPv6 of node public configs
+	ip6Errors = ipErrors{
+		ipTooShort: ErrIP6TooShort,
+		ipTooLong:  ErrIP6TooLong,
+		invalidIP:  ErrInvalidIP6,
+		gwTooShort: ErrGW6TooShort,
+		gwTooLong:  ErrGW6TooLong,
+		invalidGW:  ErrInvalidGW6,
+	}
+)
+
+// validatePublicIP checks ip is a public IPv4 in CIDR notation,
+// and gw is an address of the ip network
+func validatePublicIP(ip, gw string) error {
+	return validateIP(ip, gw, false, publicIPErrors)
+}
+
+// validatePublicConfig checks the IPv4 is a public address and the optional
+// IPv6 an address in CIDR notation, both with a gateway of their network,
+// and the optional domain is a valid domain name
+func validatePublicConfig(cfg PublicConfig) error {
+	if err := validateIP(cfg.IP4.IP, cfg.IP4.GW, false, ip4Errors); err != nil {
+		return err
+	}
+
+	if cfg.IP6.HasValue {
+		if err := validateIP(cfg.IP6.AsValue.IP, cfg.IP6.AsValue.GW, true, ip6Errors); err != nil {
+			return err
+		}
+	}
+
+	if cfg.Domain.HasValue {
+		if err := validateDomain(cfg.Domain.AsValue); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateIP mirrors the checks of the tfgrid pallet on public ips and node
+// public configs (pallets/pallet-tfgrid/src/pub_ip.rs and pub_config.rs in
+// tfchain): ip is an IPv4 (or IPv6) in CIDR notation, a public one for IPv4,
+// and gw is an address of the ip network. IPv6 gateways can also be link
+// local. Errors are wrapped sentinels of errs
+func validateIP(ip, gw string, ipv6 bool, errs ipErrors) error {
+	addr, network, err := parseIP(ip, ipv6, errs)
+	if err != nil {
+		return err
+	}
+
+	if !ipv6 && !isPublic(addr) {
+		return errors.Wrapf(errs.invalidIP, "ip '%s' is not public", ip)
+	}
+
+	minGW, maxGW, family := minGW4Length, maxGW4Length, "IPv4"
+	if ipv6 {
+		minGW, maxGW, family = minGW6Length, maxGW6Length, "IPv6"
+	}
+
+	if len(gw) < minGW {
+		return errors.Wrapf(errs.gwTooShort, "gateway '%s' is too short", gw)
+	}
+
+	if len(gw) > maxGW {
+		return errors.Wrapf(errs.gwTooLong, "gateway '%s' is too long", gw)
+	}
+
+	gateway := net.ParseIP(gw)
+	if gateway == nil || (gateway.To4() == nil) != ipv6 {
+		return errors.Wrapf(errs.invalidGW, "gateway '%s' is not an %s", gw, family)
+	}
+
+	if !network.Contains(gateway) && !(ipv6 && gateway.IsLinkLocalUnicast()) {
+		return errors.Wrapf(errs.invalidGW, "gateway '%s' is not an address of network '%s'", gw, network)
+	}
+
+	return nil
+}
+
+// parseIP checks the length of ip and parses it as an IPv4 (or IPv6) in
+// CIDR notation. Errors are wrapped sentinels of errs
+func parseIP(ip string, ipv6 bool, errs ipErrors) (net.IP, *net.IPNet, error) {
+	minIP, maxIP, family := minIP4Length, maxIP4Length, "IPv4"
+	if ipv6 {
+		minIP, maxIP, family = minIP6Length, maxIP6Length, "IPv6"
+	}
+
+	if len(ip) < minIP {
+		return nil, nil, errors.Wrapf(errs.ipTooShort, "ip '%s' is too short", ip)
+	}
+
+	if len(ip) > maxIP {
+		return nil, nil, errors.Wrapf(errs.ipTooLong, "ip '%s' is too long", ip)
+	}
+
+	addr, network, err := net.ParseCIDR(ip)
+	if err != nil || (addr.To4() == nil) != ipv6 {
+		return nil, nil, errors.Wrapf(errs.invalidIP, "ip '%s' is not an %s in CIDR notation", ip, family)
+	}
+
+	return addr, network, nil
+}
+
+// validateDomain checks domain is made of dot separated labels
+// of letters, digits and '-'
+func validateDomain(domain string) error {
+	if len(domain) < minDomainLength {
+		return errors.Wrapf(ErrDomainTooShort, "domain '%s' is too short", domain)
+	}
+
+	if len(domain) > maxDomainLength {
+		return errors.Wrapf(ErrDomainTooLong, "domain '%s' is too long", domain)
+	}
+
+	for _, label := range strings.Split(domain, ".") {
+		if len(label) == 0 || label[0] == '-' || label[len(label)-1] == '-' {
+			return errors.Wrapf(ErrInvalidDomain, "invalid domain '%s'", domain)
+		}
+
+		for _, c := range label {
+			if !isAlphanumeric(c) && c != '-' {
+				return errors.Wrapf(ErrInvalidDomain, "invalid domain '%s'", domain)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateStellarAddress checks address is a valid stellar account id
+func validateStellarAddress(address string) error {
+	if len(address) != stellarAddressLength || address[0] != 'G' {
+		return errors.Wrapf(ErrInvalidStellarPublicKey, "'%s' is not a stellar account address", address)
+	}
+
+	data, err := base32.StdEncoding.DecodeString(address)
+	if err != nil || len(data) != 35 || data[0] != stellarAccountVersion {
+		return errors.Wrapf(ErrInvalidStellarPublicKey, "'%s' is not a stellar account address", address)
+	}
+
+	payload, checksum := data[:33], data[33:]
+	crc := crc16XModem(payload)
+	if checksum[0] != byte(crc) || checksum[1] != byte(crc>>8) {
+		return errors.Wrapf(ErrInvalidStellarPublicKey, "invalid checksum of stellar address '%s'", address)
+	}
+
+	return nil
+}
+
+// crc16XModem is the checksum of stellar addresses
+func crc16XModem(data []byte) uint16 {
+	var crc uint16
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+
+	return crc
+}
+
+// sharedNetwork is the carrier grade NAT range, not routable either
+var sharedNetwork = net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// isPublic checks ip is a global unicast address out of the private ranges
+func isPublic(ip net.IP) bool {
+	return ip.IsGlobalUnicast() && !ip.IsPrivate() && !sharedNetwork.Contains(ip)
+}
+
+func isAlphanumeric(c rune) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,116 @@
+package substrate
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// stellarAddress encodes a stellar account address of key
+func stellarAddress(key [32]byte) string {
+	payload := append([]byte{stellarAccountVersion}, key[:]...)
+	crc := crc16XModem(payload)
+	return base32.StdEncoding.EncodeToString(append(payload, byte(crc), byte(crc>>8)))
+}
+
+func TestValidateFarmName(t *testing.T) {
+	require.NoError(t, validateFarmName("my_farm-01"))
+
+	require.ErrorIs(t, validateFarmName("ab"), ErrFarmNameTooShort)
+	require.ErrorIs(t, validateFarmName(strings.Repeat("a", 41)), ErrFarmNameTooLong)
+	require.ErrorIs(t, validateFarmName("my farm"), ErrInvalidFarmName)
+	require.ErrorIs(t, validateFarmName("farm.tf"), ErrInvalidFarmName)
+}
+
+func TestValidatePublicIP(t *testing.T) {
+	require.NoError(t, validatePublicIP("185.206.122.33/24", "185.206.122.1"))
+
+	for _, tc := range []struct {
+		ip, gw string
+		err    error
+	}{
+		{"1.1.1.1", "1.1.1.254", ErrPublicIPTooShort},
+		{"185.206.122.133/24/", "185.206.122.1", ErrPublicIPTooLong},
+		{"185.206.122.33/24", "1.1.1", ErrGatewayIPTooShort},
+		{"185.206.122.33/24", "185.206.122.1111", ErrGatewayIPTooLong},
+		{"185.206.122.33", "185.206.122.1", ErrInvalidPublicIP},
+		{"10.10.10.10/24", "10.10.10.1", ErrInvalidPublicIP},
+		{"100.64.10.10/24", "100.64.10.1", ErrInvalidPublicIP},
+		{"185.206.122.33/24", "185.206.123.1", ErrInvalidPublicIP},
+		{"185.206.122.33/24", "not.an.ip.x", ErrInvalidPublicIP},
+	} {
+		require.ErrorIs(t, validatePublicIP(tc.ip, tc.gw), tc.err, "%s %s", tc.ip, tc.gw)
+	}
+}
+
+func TestValidateStellarAddress(t *testing.T) {
+	var key [32]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+
+	address := stellarAddress(key)
+	require.Len(t, address, stellarAddressLength)
+	require.NoError(t, validateStellarAddress(address))
+
+	// flipped character breaks the checksum
+	broken := []byte(address)
+	if broken[10] == 'A' {
+		broken[10] = 'B'
+	} else {
+		broken[10] = 'A'
+	}
+	require.ErrorIs(t, validateStellarAddress(string(broken)), ErrInvalidStellarPublicKey)
+
+	require.ErrorIs(t, validateStellarAddress(address[:55]), ErrInvalidStellarPublicKey)
+	require.ErrorIs(t, validateStellarAddress("S"+address[1:]), ErrInvalidStellarPublicKey)
+	require.ErrorIs(t, validateStellarAddress(strings.ToLower(address)), ErrInvalidStellarPublicKey)
+}
+
+func TestValidatePublicConfig(t *testing.T) {
+	valid := PublicConfig{
+		IP4:    IP{IP: "185.206.122.33/24", GW: "185.206.122.1"},
+		IP6:    OptionIP{HasValue: true, AsValue: IP{IP: "2a10:b600:1::33/64", GW: "2a10:b600:1::1"}},
+		Domain: OptionDomain{HasValue: true, AsValue: "node-1.grid.tf"},
+	}
+	require.NoError(t, validatePublicConfig(valid))
+	require.NoError(t, validatePublicConfig(PublicConfig{IP4: valid.IP4}))
+
+	// the pallet accepts link local gateways and private networks for IPv6
+	linkLocal := valid
+	linkLocal.IP6.AsValue.GW = "fe80::1"
+	require.NoError(t, validatePublicConfig(linkLocal))
+	private := valid
+	private.IP6.AsValue = IP{IP: "fd00::33/64", GW: "fd00::1"}
+	require.NoError(t, validatePublicConfig(private))
+
+	for _, tc := range []struct {
+		name   string
+		mutate func(cfg *PublicConfig)
+		err    error
+	}{
+		{"short ip4", func(cfg *PublicConfig) { cfg.IP4.IP = "1.1.1.1" }, ErrIP4TooShort},
+		{"long ip4", func(cfg *PublicConfig) { cfg.IP4.IP = "185.206.122.133/24/" }, ErrIP4TooLong},
+		{"short gw4", func(cfg *PublicConfig) { cfg.IP4.GW = "1.1.1" }, ErrGW4TooShort},
+		{"long gw4", func(cfg *PublicConfig) { cfg.IP4.GW = "185.206.122.1111" }, ErrGW4TooLong},
+		{"ip4 not cidr", func(cfg *PublicConfig) { cfg.IP4.IP = "185.206.122.33" }, ErrInvalidIP4},
+		{"private ip4", func(cfg *PublicConfig) { cfg.IP4 = IP{IP: "192.168.1.10/24", GW: "192.168.1.1"} }, ErrInvalidIP4},
+		{"gw4 out of network", func(cfg *PublicConfig) { cfg.IP4.GW = "185.206.123.1" }, ErrInvalidGW4},
+		{"long ip6", func(cfg *PublicConfig) { cfg.IP6.AsValue.IP = "2a10:b600:0001:0001:0001:0001:185.206.122.33/128" }, ErrIP6TooLong},
+		{"short gw6", func(cfg *PublicConfig) { cfg.IP6.AsValue.GW = "1" }, ErrGW6TooShort},
+		{"ip6 not cidr", func(cfg *PublicConfig) { cfg.IP6.AsValue.IP = "2a10:b600:1::33" }, ErrInvalidIP6},
+		{"ip4 as ip6", func(cfg *PublicConfig) { cfg.IP6.AsValue.IP = "185.206.122.33/24" }, ErrInvalidIP6},
+		{"gw6 out of network", func(cfg *PublicConfig) { cfg.IP6.AsValue.GW = "2a10:b600:2::1" }, ErrInvalidGW6},
+		{"gw4 as gw6", func(cfg *PublicConfig) { cfg.IP6.AsValue.GW = "185.206.122.1" }, ErrInvalidGW6},
+		{"short domain", func(cfg *PublicConfig) { cfg.Domain.AsValue = "tf" }, ErrDomainTooShort},
+		{"long domain", func(cfg *PublicConfig) { cfg.Domain.AsValue = strings.Repeat("a.", 64) + "tf" }, ErrDomainTooLong},
+		{"invalid domain", func(cfg *PublicConfig) { cfg.Domain.AsValue = "node_1.grid.tf" }, ErrInvalidDomain},
+		{"empty label", func(cfg *PublicConfig) { cfg.Domain.AsValue = "node..grid.tf" }, ErrInvalidDomain},
+	} {
+		cfg := valid
+		tc.mutate(&cfg)
+		require.ErrorIs(t, validatePublicConfig(cfg), tc.err, tc.name)
+	}
+}
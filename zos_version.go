@@ -1,13 +1,19 @@
 package substrate
 
 import (
+	"context"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/pkg/errors"
 )
 
 // GetZosVersion gets the latest version for each network
 func (s *Substrate) GetZosVersion() (string, error) {
-	cl, meta, err := s.GetClient()
+	return s.GetZosVersionCtx(context.Background())
+}
+
+// GetZosVersionCtx is like GetZosVersion but takes a context
+func (s *Substrate) GetZosVersionCtx(ctx context.Context) (string, error) {
+	cl, meta, err := s.getClient(ctx)
 	if err != nil {
 		return "", err
 	}
@@ -17,7 +23,7 @@ func (s *Substrate) GetZosVersion() (string, error) {
 		return "", errors.Wrap(err, "failed to create substrate query key")
 	}
 
-	raw, err := cl.RPC.State.GetStorageRawLatest(key)
+	raw, err := s.getStorageRaw(cl, key)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to lookup entity")
 	}